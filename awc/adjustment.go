@@ -0,0 +1,46 @@
+package awc
+
+import "fmt"
+
+// QueryAdjustment describes an out-of-range input value of a query that was clamped to the nearest valid value
+type QueryAdjustment struct {
+	Parameter     string
+	Value         float32
+	AdjustedValue float32
+}
+
+func (adjustment QueryAdjustment) String() string {
+	return fmt.Sprintf("%s: %f is out of range and was adjusted to %f", adjustment.Parameter, adjustment.Value,
+		adjustment.AdjustedValue)
+}
+
+// adjustments records the QueryAdjustment objects of a query.
+// Every setter clearing a constraint is expected to clear the adjustments of the respective parameters as well.
+type adjustments []QueryAdjustment
+
+func (adj *adjustments) record(parameter string, value, adjustedValue float32) {
+	if value != adjustedValue {
+		*adj = append(*adj, QueryAdjustment{
+			Parameter:     parameter,
+			Value:         value,
+			AdjustedValue: adjustedValue,
+		})
+	}
+}
+
+func (adj *adjustments) clear(parameters ...string) {
+	var kept adjustments
+	for _, adjustment := range *adj {
+		cleared := false
+		for _, parameter := range parameters {
+			if adjustment.Parameter == parameter {
+				cleared = true
+				break
+			}
+		}
+		if !cleared {
+			kept = append(kept, adjustment)
+		}
+	}
+	*adj = kept
+}
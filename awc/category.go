@@ -0,0 +1,145 @@
+package awc
+
+// The flight categories reported by the AWC Text Data Server
+const (
+	FlightCategoryVFR  = "VFR"
+	FlightCategoryMVFR = "MVFR"
+	FlightCategoryIFR  = "IFR"
+	FlightCategoryLIFR = "LIFR"
+)
+
+// FlightCategoryUnknown is used by StatusCode and METARResponse.GroupByFlightCategory for METARs whose flight category
+// is unknown; it is never reported by the server
+const FlightCategoryUnknown = "UNKNOWN"
+
+// flightCategorySeverity ranks a flight category from 1 (VFR) to 4 (LIFR).
+// 0 is returned for unknown or empty categories.
+func flightCategorySeverity(category string) int {
+	switch category {
+	case FlightCategoryVFR:
+		return 1
+	case FlightCategoryMVFR:
+		return 2
+	case FlightCategoryIFR:
+		return 3
+	case FlightCategoryLIFR:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// flightCategoryByCeiling determines the flight category solely based on a ceiling in feet
+func flightCategoryByCeiling(ceilingFT int) string {
+	switch {
+	case ceilingFT < 500:
+		return FlightCategoryLIFR
+	case ceilingFT < 1000:
+		return FlightCategoryIFR
+	case ceilingFT <= 3000:
+		return FlightCategoryMVFR
+	default:
+		return FlightCategoryVFR
+	}
+}
+
+// flightCategoryByVisibility determines the flight category solely based on a visibility in statute miles
+func flightCategoryByVisibility(visibilitySM float32) string {
+	switch {
+	case visibilitySM < 1:
+		return FlightCategoryLIFR
+	case visibilitySM < 3:
+		return FlightCategoryIFR
+	case visibilitySM <= 5:
+		return FlightCategoryMVFR
+	default:
+		return FlightCategoryVFR
+	}
+}
+
+// hasVisibility reports whether the METAR contains a visibility.
+// As a visibility of 0 can not be distinguished from a missing one using the parsed field, the raw text is checked for
+// a zero visibility group ('0SM' or '0000') in that case.
+func (metar *METAR) hasVisibility() bool {
+	if metar.VisibilityStatuteMI > 0 {
+		return true
+	}
+	for _, group := range metar.body() {
+		if group == "0SM" || group == "0000" {
+			return true
+		}
+	}
+	return false
+}
+
+// ComputeFlightCategory determines the flight category of the METAR from its ceiling and visibility using the FAA
+// thresholds:
+//   - LIFR: ceiling below 500 ft or visibility below 1 SM
+//   - IFR: ceiling below 1000 ft or visibility below 3 SM
+//   - MVFR: ceiling up to 3000 ft or visibility up to 5 SM
+//   - VFR: otherwise
+//
+// The absence of a ceiling counts as an unlimited ceiling if any sky condition is reported.
+// An empty string is returned if the category can not be determined because the sky conditions or visibility are
+// missing, unless the known value already results in LIFR.
+func (metar *METAR) ComputeFlightCategory() string {
+	ceilingCategory := ""
+	if ceiling, ok := metar.Ceiling(); ok {
+		ceilingCategory = flightCategoryByCeiling(ceiling)
+	} else if len(metar.SkyConditions) > 0 {
+		ceilingCategory = FlightCategoryVFR
+	}
+
+	visibilityCategory := ""
+	if metar.hasVisibility() {
+		visibilityCategory = flightCategoryByVisibility(metar.VisibilityStatuteMI)
+	}
+
+	if ceilingCategory == FlightCategoryLIFR || visibilityCategory == FlightCategoryLIFR {
+		return FlightCategoryLIFR
+	}
+	if ceilingCategory == "" || visibilityCategory == "" {
+		return ""
+	}
+	if flightCategorySeverity(ceilingCategory) > flightCategorySeverity(visibilityCategory) {
+		return ceilingCategory
+	}
+	return visibilityCategory
+}
+
+// The default buffers used by IsMarginal
+const (
+	defaultMarginalCeilingBufferFT    = 200
+	defaultMarginalVisibilityBufferSM = 1
+)
+
+// IsMarginal reports whether the METAR reports marginal conditions that could slip below VFR, using a buffer of 200
+// feet and 1 statute mile around the IFR thresholds.
+// Please refer to IsMarginalWithin for further information.
+func (metar *METAR) IsMarginal() bool {
+	return metar.IsMarginalWithin(defaultMarginalCeilingBufferFT, defaultMarginalVisibilityBufferSM)
+}
+
+// IsMarginalWithin reports whether the METAR reports marginal conditions that could slip below VFR.
+// This is the case for MVFR conditions and for conditions within a buffer around the IFR thresholds, i.e. a ceiling
+// of 1000 feet or a visibility of 3 statute miles: neither the ceiling may be below 1000 feet minus ceilingBufferFT
+// nor the visibility below 3 statute miles minus visibilityBufferSM, and at least one of them has to be below the
+// threshold plus its buffer. This makes IFR conditions just below the thresholds marginal as well, while LIFR
+// conditions never are. The flight category is computed using ComputeFlightCategory if the server omitted it.
+func (metar *METAR) IsMarginalWithin(ceilingBufferFT int, visibilityBufferSM float32) bool {
+	switch metar.flightCategory() {
+	case FlightCategoryMVFR:
+		return true
+	case FlightCategoryLIFR:
+		return false
+	}
+
+	ceiling, hasCeiling := metar.Ceiling()
+	hasVisibility := metar.hasVisibility()
+	if (hasCeiling && ceiling < 1000-ceilingBufferFT) ||
+		(hasVisibility && metar.VisibilityStatuteMI < 3-visibilityBufferSM) {
+		return false
+	}
+	return (hasCeiling && ceiling < 1000+ceilingBufferFT) ||
+		(hasVisibility && metar.VisibilityStatuteMI < 3+visibilityBufferSM)
+}
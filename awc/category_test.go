@@ -0,0 +1,64 @@
+package awc
+
+import "testing"
+
+func TestIsMarginal(t *testing.T) {
+	tests := []struct {
+		raw                     string
+		marginal, marginalNoBuf bool
+	}{
+		{"KSFO 011256Z 28012KT 10SM SKC 15/08 A3002", false, false},
+		{"KSFO 011256Z 28012KT 10SM BKN032 15/08 A3002", false, false},
+		{"KSFO 011256Z 28012KT 5SM BR FEW100 15/08 A3002", true, true},
+		{"KSFO 011256Z 28012KT 10SM BKN020 15/08 A3002", true, true},
+		{"KSFO 011256Z 28012KT 10SM BKN010 15/08 A3002", true, true},
+		{"KSFO 011256Z 28012KT 3SM BR FEW100 15/08 A3002", true, true},
+		{"KSFO 011256Z 28012KT 10SM OVC009 15/08 A3002", true, false},
+		{"KSFO 011256Z 28012KT 10SM OVC008 15/08 A3002", true, false},
+		{"KSFO 011256Z 28012KT 10SM OVC007 15/08 A3002", false, false},
+		{"KSFO 011256Z 28012KT 2 1/2SM BR FEW100 15/08 A3002", true, false},
+		{"KSFO 011256Z 28012KT 2SM BR FEW100 15/08 A3002", true, false},
+		{"KSFO 011256Z 28012KT 1 1/2SM BR FEW100 15/08 A3002", false, false},
+		{"KSFO 011256Z 28012KT 2SM BR OVC009 15/08 A3002", true, false},
+		{"KSFO 011256Z 28012KT 1SM BR OVC009 15/08 A3002", false, false},
+		{"KSFO 011256Z 28012KT 1/2SM FG VV002 15/15 A3002", false, false},
+	}
+	for _, test := range tests {
+		metar := mustParseMETAR(t, test.raw)
+		if got := metar.IsMarginal(); got != test.marginal {
+			t.Errorf("IsMarginal(%q) = %t; want %t", test.raw, got, test.marginal)
+		}
+		if got := metar.IsMarginalWithin(0, 0); got != test.marginalNoBuf {
+			t.Errorf("IsMarginalWithin(%q, 0, 0) = %t; want %t", test.raw, got, test.marginalNoBuf)
+		}
+	}
+}
+
+func TestIsMarginalPrefersReportedCategory(t *testing.T) {
+	metar := mustParseMETAR(t, "KSFO 011256Z 28012KT 10SM BKN011 15/08 A3002")
+	metar.FlightCategory = FlightCategoryVFR
+	if !metar.IsMarginal() {
+		t.Error("expected a reported VFR category with a ceiling of 1100 ft to be marginal")
+	}
+	if metar.IsMarginalWithin(0, 0) {
+		t.Error("expected a reported VFR category with a ceiling of 1100 ft not to be marginal without a buffer")
+	}
+	metar.FlightCategory = FlightCategoryLIFR
+	if metar.IsMarginal() {
+		t.Error("expected the reported LIFR category not to be marginal")
+	}
+
+	metar = mustParseMETAR(t, "KSFO 011256Z 28012KT 10SM BKN032 15/08 A3002")
+	metar.FlightCategory = FlightCategoryMVFR
+	if !metar.IsMarginalWithin(0, 0) {
+		t.Error("expected the reported MVFR category to be marginal")
+	}
+	metar.FlightCategory = FlightCategoryIFR
+	if metar.IsMarginal() {
+		t.Error("expected the reported IFR category with a ceiling of 3200 ft not to be marginal")
+	}
+	metar.FlightCategory = ""
+	if !metar.IsMarginalWithin(2500, 0) {
+		t.Error("expected a ceiling of 3200 ft to be marginal within a buffer of 2500 ft")
+	}
+}
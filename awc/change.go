@@ -0,0 +1,136 @@
+package awc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChangeKind represents the kind of a significant change between two observations
+type ChangeKind string
+
+// The kinds of changes detected by SignificantChange
+const (
+	ChangeFlightCategory     ChangeKind = "flight_category"
+	ChangePrecipitationStart ChangeKind = "precipitation_start"
+	ChangePrecipitationEnd   ChangeKind = "precipitation_end"
+	ChangeWindDirection      ChangeKind = "wind_direction"
+	ChangeWindSpeed          ChangeKind = "wind_speed"
+	ChangeVisibility         ChangeKind = "visibility"
+)
+
+// precipitationCodes contains the weather phenomena considered precipitation
+var precipitationCodes = []string{"DZ", "RA", "SN", "SG", "IC", "PL", "GR", "GS", "UP"}
+
+// ChangeThresholds specifies how much the wind and visibility have to change to be considered significant.
+// Thresholds of 0 disable the respective comparison; flight category changes and the start or end of precipitation are
+// always reported.
+type ChangeThresholds struct {
+	// WindDirectionDegrees is the minimal wind shift; it is only compared if both observations report a directional
+	// (i.e. neither calm nor variable) wind.
+	WindDirectionDegrees int
+	// WindSpeedKT is the minimal increase or decrease of the sustained wind speed
+	WindSpeedKT int
+	// VisibilitySM is the minimal increase or decrease of the visibility
+	VisibilitySM float32
+}
+
+// Change represents a single significant change between two observations
+type Change struct {
+	Kind ChangeKind
+	// Description renders the change in plain English, e.g. "flight category changed from VFR to IFR"
+	Description string
+}
+
+// SignificantChange compares two consecutive observations of a station and returns all significant changes in the
+// order flight category, precipitation, wind direction, wind speed and visibility.
+// The flight categories are computed using ComputeFlightCategory if the server omitted them. nil is returned if either
+// METAR is nil or nothing changed significantly.
+func SignificantChange(prev, curr *METAR, thresholds ChangeThresholds) []Change {
+	if prev == nil || curr == nil {
+		return nil
+	}
+
+	var changes []Change
+	add := func(kind ChangeKind, format string, args ...interface{}) {
+		changes = append(changes, Change{Kind: kind, Description: fmt.Sprintf(format, args...)})
+	}
+
+	prevCategory, currCategory := prev.flightCategory(), curr.flightCategory()
+	if prevCategory != "" && currCategory != "" && prevCategory != currCategory {
+		add(ChangeFlightCategory, "flight category changed from %s to %s", prevCategory, currCategory)
+	}
+
+	prevPrecipitation, currPrecipitation := prev.precipitation(), curr.precipitation()
+	if len(prevPrecipitation) == 0 && len(currPrecipitation) > 0 {
+		add(ChangePrecipitationStart, "%s began", strings.Join(currPrecipitation, " and "))
+	} else if len(prevPrecipitation) > 0 && len(currPrecipitation) == 0 {
+		add(ChangePrecipitationEnd, "%s ended", strings.Join(prevPrecipitation, " and "))
+	}
+
+	if thresholds.WindDirectionDegrees > 0 {
+		_, _, prevDirectional := prev.WindVector()
+		_, _, currDirectional := curr.WindVector()
+		if prevDirectional && currDirectional &&
+			angularDifference(prev.WindDirDegrees, curr.WindDirDegrees) >= thresholds.WindDirectionDegrees {
+			add(ChangeWindDirection, "wind shifted from %03d to %03d degrees", prev.WindDirDegrees, curr.WindDirDegrees)
+		}
+	}
+
+	if thresholds.WindSpeedKT > 0 && !prev.IsWindMissing() && !curr.IsWindMissing() {
+		delta := curr.WindSpeedKT - prev.WindSpeedKT
+		if delta >= thresholds.WindSpeedKT {
+			add(ChangeWindSpeed, "wind increased from %d to %d knots", prev.WindSpeedKT, curr.WindSpeedKT)
+		} else if -delta >= thresholds.WindSpeedKT {
+			add(ChangeWindSpeed, "wind decreased from %d to %d knots", prev.WindSpeedKT, curr.WindSpeedKT)
+		}
+	}
+
+	if thresholds.VisibilitySM > 0 && prev.hasVisibility() && curr.hasVisibility() {
+		delta := curr.VisibilityStatuteMI - prev.VisibilityStatuteMI
+		previous, current := formatNumber(prev.VisibilityStatuteMI), formatNumber(curr.VisibilityStatuteMI)
+		if delta >= thresholds.VisibilitySM {
+			add(ChangeVisibility, "visibility increased from %s to %s miles", previous, current)
+		} else if -delta >= thresholds.VisibilitySM {
+			add(ChangeVisibility, "visibility decreased from %s to %s miles", previous, current)
+		}
+	}
+
+	return changes
+}
+
+// flightCategory returns the flight category reported by the server or, if absent, the computed one
+func (metar *METAR) flightCategory() string {
+	if metar.FlightCategory != "" {
+		return metar.FlightCategory
+	}
+	return metar.ComputeFlightCategory()
+}
+
+// precipitation returns the descriptions of all precipitation observed at the station (not in its vicinity)
+func (metar *METAR) precipitation() []string {
+	var descriptions []string
+	for _, phenomenon := range metar.Weather() {
+		if phenomenon.Vicinity {
+			continue
+		}
+		for _, code := range phenomenon.Phenomena {
+			if containsString(precipitationCodes, code) {
+				descriptions = append(descriptions, phenomenon.Description())
+				break
+			}
+		}
+	}
+	return descriptions
+}
+
+// angularDifference returns the smallest difference between two directions in degrees
+func angularDifference(a, b int) int {
+	difference := (a - b) % 360
+	if difference < 0 {
+		difference += 360
+	}
+	if difference > 180 {
+		difference = 360 - difference
+	}
+	return difference
+}
@@ -0,0 +1,93 @@
+package awc
+
+import (
+	"reflect"
+	"testing"
+)
+
+// mustParseMETAR parses a raw METAR report resolved against testNow, failing the test on errors
+func mustParseMETAR(t *testing.T, raw string) *METAR {
+	t.Helper()
+	metar, err := ParseMETARAt(raw, testNow)
+	if err != nil {
+		t.Fatalf("ParseMETARAt(%q) failed: %v", raw, err)
+	}
+	return metar
+}
+
+func TestSignificantChange(t *testing.T) {
+	thresholds := ChangeThresholds{WindDirectionDegrees: 45, WindSpeedKT: 10, VisibilitySM: 2}
+	tests := []struct {
+		name       string
+		prev, curr string
+		thresholds ChangeThresholds
+		want       []Change
+	}{
+		{
+			"nothing changed",
+			"KSFO 011156Z 28012KT 10SM FEW020 15/08 A3002",
+			"KSFO 011256Z 30014KT 10SM SCT020 15/08 A3002",
+			thresholds,
+			nil,
+		},
+		{
+			"category flip and onset of precipitation",
+			"KSFO 011156Z 28012KT 10SM FEW020 15/08 A3002",
+			"KSFO 011256Z 28012KT 2SM -RA BR OVC008 12/11 A2998",
+			thresholds,
+			[]Change{
+				{ChangeFlightCategory, "flight category changed from VFR to IFR"},
+				{ChangePrecipitationStart, "light rain began"},
+				{ChangeVisibility, "visibility decreased from 10 to 2 miles"},
+			},
+		},
+		{
+			"end of precipitation",
+			"KSFO 011156Z 28012KT 6SM -RA BKN040 12/11 A2998",
+			"KSFO 011256Z 28012KT 7SM VCSH BKN040 12/11 A2998",
+			thresholds,
+			[]Change{{ChangePrecipitationEnd, "light rain ended"}},
+		},
+		{
+			"wind shift and increase",
+			"KSFO 011156Z 35008KT 10SM FEW020 15/08 A3002",
+			"KSFO 011256Z 04020G30KT 10SM FEW020 15/08 A3002",
+			thresholds,
+			[]Change{
+				{ChangeWindDirection, "wind shifted from 350 to 040 degrees"},
+				{ChangeWindSpeed, "wind increased from 8 to 20 knots"},
+			},
+		},
+		{
+			"shift below the threshold across north",
+			"KSFO 011156Z 35008KT 10SM FEW020 15/08 A3002",
+			"KSFO 011256Z 02008KT 10SM FEW020 15/08 A3002",
+			thresholds,
+			nil,
+		},
+		{
+			"variable wind is not compared",
+			"KSFO 011156Z 35008KT 10SM FEW020 15/08 A3002",
+			"KSFO 011256Z VRB03KT 10SM FEW020 15/08 A3002",
+			thresholds,
+			nil,
+		},
+		{
+			"disabled thresholds",
+			"KSFO 011156Z 35008KT 10SM FEW020 15/08 A3002",
+			"KSFO 011256Z 17030KT 3SM FEW020 15/08 A3002",
+			ChangeThresholds{},
+			[]Change{{ChangeFlightCategory, "flight category changed from VFR to MVFR"}},
+		},
+	}
+	for _, test := range tests {
+		got := SignificantChange(mustParseMETAR(t, test.prev), mustParseMETAR(t, test.curr), test.thresholds)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %+v; want %+v", test.name, got, test.want)
+		}
+	}
+
+	if changes := SignificantChange(nil, &METAR{}, thresholds); changes != nil {
+		t.Errorf("expected nil for a nil METAR, got %+v", changes)
+	}
+}
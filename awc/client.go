@@ -0,0 +1,669 @@
+package awc
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/html/charset"
+)
+
+var defaultClient = new(Client)
+
+const (
+	// defaultMaxConnsPerHost is the maximum amount of simultaneous connections to the server of an own transport
+	defaultMaxConnsPerHost = 8
+	// defaultMaxIdleConnsPerHost is the maximum amount of idle connections to the server kept by an own transport
+	defaultMaxIdleConnsPerHost = 8
+)
+
+// WarningsError is returned instead of a METARResponse if the server reported warnings and the client treats them as
+// errors.
+// Please refer to Client.WarningsAsErrors for further information.
+type WarningsError struct {
+	Warnings []string
+}
+
+func (err *WarningsError) Error() string {
+	return fmt.Sprintf("api warning(s): %s", strings.Join(err.Warnings, "; "))
+}
+
+// ErrNoData is returned if a query succeeded but did not yield any observations.
+// Please refer to Client.ErrorOnNoData for the methods affected by this.
+var ErrNoData = errors.New("no data")
+
+// Client represents a client used to execute queries against the AWC Text Data Server.
+// The zero value is ready to use and sends its requests through http.DefaultClient. Options that require an own
+// transport keep honoring the standard proxy environment variables (HTTP_PROXY, HTTPS_PROXY and NO_PROXY).
+// Requests negotiate the content type matching the requested format using the Accept header; responses carrying a
+// different Content-Type are rejected with an error.
+// Please keep in mind that a Client should not be re-configured while it is being used concurrently.
+type Client struct {
+	baseURL             *string
+	httpClient          *http.Client
+	insecureSkipVerify  bool
+	ipVersion           IPVersion
+	maxConnsPerHost     *int
+	maxIdleConnsPerHost *int
+	now                 func() time.Time
+	onRequest           func(*http.Request)
+	onResponse          func(*http.Response, []byte)
+	units               UnitSystem
+	conditional         bool
+	errorOnNoData       bool
+	checkSchema         bool
+	retry               *RetryConfig
+	headers             http.Header
+	warningsAsErrors    bool
+
+	httpClientMutex sync.Mutex
+	builtHTTPClient *http.Client
+
+	cacheMutex sync.Mutex
+	cache      map[string]cachedResponse
+
+	stationsMutex sync.Mutex
+	stations      map[string]*Station
+}
+
+// IPVersion represents the IP version(s) used to connect to the server
+type IPVersion int
+
+const (
+	// IPVersionAny uses both IPv4 and IPv6
+	IPVersionAny IPVersion = iota
+	// IPVersion4 uses IPv4 only
+	IPVersion4
+	// IPVersion6 uses IPv6 only
+	IPVersion6
+)
+
+// restrict wraps dial so that TCP connections are only established using the IP version
+func (version IPVersion) restrict(
+	dial func(ctx context.Context, network, address string) (net.Conn, error),
+) func(ctx context.Context, network, address string) (net.Conn, error) {
+	suffix := ""
+	switch version {
+	case IPVersion4:
+		suffix = "4"
+	case IPVersion6:
+		suffix = "6"
+	default:
+		return dial
+	}
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		if network == "tcp" {
+			network += suffix
+		}
+		return dial(ctx, network, address)
+	}
+}
+
+// cachedResponse represents the last successful response of an URL, used for conditional requests
+type cachedResponse struct {
+	body      []byte
+	validFrom string
+}
+
+// NewClient creates a new Client ready for chaining.
+// Using the zero value of Client directly is still valid.
+func NewClient() *Client {
+	return new(Client)
+}
+
+// BaseURL specifies the base URL of the data server to send requests to.
+// This defaults to the official AWC Text Data Server and is mainly useful for testing.
+func (client *Client) BaseURL(value string) *Client {
+	value = strings.TrimSuffix(value, "?")
+	client.baseURL = &value
+	return client
+}
+
+// HTTPClient specifies the HTTP client used to send requests.
+// If a custom HTTP client is used, transport-level options like InsecureSkipVerify will be ignored.
+func (client *Client) HTTPClient(value *http.Client) *Client {
+	client.httpClient = value
+	client.resetHTTPClient()
+	return client
+}
+
+// InsecureSkipVerify specifies whether to skip the TLS certificate verification of the server.
+// This is intended for testing against local servers using self-signed certificates only and should never be enabled
+// in production.
+// It never affects http.DefaultClient; an own transport is used instead.
+func (client *Client) InsecureSkipVerify(value bool) *Client {
+	client.insecureSkipVerify = value
+	client.resetHTTPClient()
+	return client
+}
+
+// IPVersion restricts the IP version used to connect to the server.
+// This defaults to IPVersionAny, i.e. dual-stack, and is intended as a workaround for hosts with broken IPv4 or IPv6
+// connectivity. Just like InsecureSkipVerify, it never affects http.DefaultClient and is ignored if a custom HTTP
+// client is used.
+func (client *Client) IPVersion(value IPVersion) *Client {
+	client.ipVersion = value
+	client.resetHTTPClient()
+	return client
+}
+
+// Clock specifies the function used to determine the current time, e.g. when calculating the age of a METAR.
+// This defaults to time.Now and is mainly useful to freeze the time in tests.
+// Please keep in mind that HoursBeforeNow is evaluated by the server and thus not affected by this.
+func (client *Client) Clock(now func() time.Time) *Client {
+	client.now = now
+	return client
+}
+
+// OnRequest specifies a hook that gets called with every request right before it is sent.
+// This is intended for debugging and logging purposes; the hook should not modify the request.
+func (client *Client) OnRequest(hook func(*http.Request)) *Client {
+	client.onRequest = hook
+	return client
+}
+
+// OnResponse specifies a hook that gets called with every received response and its raw body, regardless of its status
+// code.
+// This is intended for debugging and logging purposes; the body of the passed response has already been consumed.
+// For streamed responses (StreamMETARCSV), the hook is called with a nil body before the body is consumed.
+func (client *Client) OnResponse(hook func(*http.Response, []byte)) *Client {
+	client.onResponse = hook
+	return client
+}
+
+// Headers specifies additional headers sent with every request, e.g. tokens required by an API gateway or tracing IDs.
+// The given headers are copied, so modifying them afterwards does not affect the client. They take precedence over the
+// Accept header set by the client, but not over the If-Modified-Since header of conditional requests.
+func (client *Client) Headers(value http.Header) *Client {
+	client.headers = value.Clone()
+	return client
+}
+
+// Units specifies the unit system the Converted field of every fetched METAR is populated in.
+// This defaults to UnitSystemNone, leaving the Converted field nil.
+func (client *Client) Units(value UnitSystem) *Client {
+	client.units = value
+	return client
+}
+
+// ConditionalRequests specifies whether to send conditional requests.
+// If enabled, the last successful response of every requested URL is kept and the If-Modified-Since header is set to
+// its Last-Modified header (or, if absent, the time it was received at). If the server responds with 304 Not Modified,
+// the kept response is returned instead.
+// Please keep in mind that the server may ignore the header, in which case every request results in a full response
+// just like without this option.
+func (client *Client) ConditionalRequests(value bool) *Client {
+	client.conditional = value
+	return client
+}
+
+// ErrorOnNoData specifies whether GetMETAR and its variants return ErrNoData if the response contains neither any
+// METARs nor any server errors.
+// This defaults to false, meaning that an empty METARResponse is returned.
+// Please keep in mind that GetLatestMETAR and GetNearestMETAR always return an error wrapping ErrNoData if no METAR was
+// found.
+func (client *Client) ErrorOnNoData(value bool) *Client {
+	client.errorOnNoData = value
+	return client
+}
+
+// WarningsAsErrors specifies whether GetMETAR and its variants return a *WarningsError if the response contains any
+// warnings, including the ones appended by CheckSchema.
+// This defaults to false and is intended for strict ingestion pipelines that should fail on any anomaly.
+func (client *Client) WarningsAsErrors(value bool) *Client {
+	client.warningsAsErrors = value
+	return client
+}
+
+// CheckSchema specifies whether to check responses for XML elements this package does not know.
+// If enabled, a warning listing the paths of all unknown elements (e.g. "data>METAR>new_field") is appended to the
+// Warnings of every METARResponse containing any. This is intended to get notice of upstream format changes early and
+// defaults to false, as the server may add benign elements at any time.
+func (client *Client) CheckSchema(value bool) *Client {
+	client.checkSchema = value
+	return client
+}
+
+func (client *Client) getNow() time.Time {
+	if client.now != nil {
+		return client.now()
+	}
+	return time.Now()
+}
+
+func (client *Client) getBaseURL() string {
+	if client.baseURL != nil {
+		return *client.baseURL
+	}
+	return defaultBaseURL
+}
+
+func (client *Client) getHTTPClient() *http.Client {
+	if client.httpClient != nil {
+		return client.httpClient
+	}
+	if !client.insecureSkipVerify && client.ipVersion == IPVersionAny && client.maxConnsPerHost == nil &&
+		client.maxIdleConnsPerHost == nil {
+		return http.DefaultClient
+	}
+
+	client.httpClientMutex.Lock()
+	defer client.httpClientMutex.Unlock()
+	if client.builtHTTPClient == nil {
+		client.builtHTTPClient = &http.Client{Transport: client.newTransport()}
+	}
+	return client.builtHTTPClient
+}
+
+// resetHTTPClient discards the HTTP client built by getHTTPClient, so that changed options are applied to the next
+// request
+func (client *Client) resetHTTPClient() {
+	client.httpClientMutex.Lock()
+	client.builtHTTPClient = nil
+	client.httpClientMutex.Unlock()
+}
+
+// MaxConnsPerHost limits the amount of simultaneous connections to the server, including the ones being dialed.
+// A value of 0 or less applies the default limit of 8.
+// Requests exceeding the limit wait for a connection to become available instead of opening another socket, so fanning
+// out more goroutines than allowed (e.g. one per station of a large batch) only queues the surplus requests. The
+// waiting time counts towards the deadline of their context and, if RetryConfig.AttemptTimeout is set, of the attempt.
+// Just like InsecureSkipVerify, calling this never affects http.DefaultClient; an own transport is used instead, which
+// applies the defaults of MaxConnsPerHost and MaxIdleConnsPerHost unless the respective option specifies another limit.
+// Please keep in mind that http.DefaultClient, which is used by the zero value without any transport-level option,
+// does not limit the connections at all. The limits are ignored if a custom HTTP client is used.
+func (client *Client) MaxConnsPerHost(value int) *Client {
+	client.maxConnsPerHost = &value
+	client.resetHTTPClient()
+	return client
+}
+
+// MaxIdleConnsPerHost limits the amount of idle connections to the server kept for reuse.
+// A value of 0 or less applies the default limit of 8. Please refer to MaxConnsPerHost for how the limits are applied.
+func (client *Client) MaxIdleConnsPerHost(value int) *Client {
+	client.maxIdleConnsPerHost = &value
+	client.resetHTTPClient()
+	return client
+}
+
+// newTransport creates the transport used if the options of the client can not be applied to http.DefaultClient.
+// It is a clone of http.DefaultTransport and thus honors the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables
+// just like it.
+func (client *Client) newTransport() *http.Transport {
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if ok {
+		transport = transport.Clone()
+	} else {
+		transport = &http.Transport{Proxy: http.ProxyFromEnvironment, ForceAttemptHTTP2: true}
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	transport.DialContext = client.ipVersion.restrict(dialer.DialContext)
+	transport.MaxConnsPerHost = defaultMaxConnsPerHost
+	transport.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	if client.maxConnsPerHost != nil && *client.maxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = *client.maxConnsPerHost
+	}
+	if client.maxIdleConnsPerHost != nil && *client.maxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = *client.maxIdleConnsPerHost
+	}
+	if client.insecureSkipVerify {
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = new(tls.Config)
+		}
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	}
+	return transport
+}
+
+// newRequest creates a request to the given endpoint accepting the content types matching its format
+func (client *Client) newRequest(ctx context.Context, end endpoint) (*http.Request, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, end.withBase(client.getBaseURL()), nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", end.accept())
+	for key, values := range client.headers {
+		request.Header[key] = append([]string(nil), values...)
+	}
+	return request, nil
+}
+
+// send calls the OnRequest hook and sends the request, retrying it according to the RetryConfig of the client.
+// If the request failed because its context is done, the error of the context is returned.
+func (client *Client) send(request *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		if client.onRequest != nil {
+			client.onRequest(request)
+		}
+		response, err := client.attempt(request)
+
+		ctx := request.Context()
+		if client.retry == nil || attempt >= client.retry.MaxAttempts || !isRetryable(ctx, response, err) {
+			if err != nil && ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return response, err
+		}
+
+		wait := client.retryWait(attempt, response)
+		if response != nil {
+			client.discard(response)
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// stream sends a request to the given endpoint and passes the body of the successful response to consume.
+// In contrast to fetch, the body is not buffered; thus, the OnResponse hook receives a nil body and conditional
+// requests are not supported.
+func (client *Client) stream(ctx context.Context, end endpoint, consume func(body io.Reader) error) error {
+	request, err := client.newRequest(ctx, end)
+	if err != nil {
+		return err
+	}
+
+	httpResponse, err := client.send(request)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+
+	if client.onResponse != nil {
+		client.onResponse(httpResponse, nil)
+	}
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		return errors.New(fmt.Sprintf("unexpected status code: %d", httpResponse.StatusCode))
+	}
+	if err := end.checkContentType(httpResponse.Header.Get("Content-Type")); err != nil {
+		return err
+	}
+	return consume(httpResponse.Body)
+}
+
+// fetch sends a request to the given endpoint and returns the body of the successful response
+func (client *Client) fetch(ctx context.Context, end endpoint) ([]byte, error) {
+	request, err := client.newRequest(ctx, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var cached *cachedResponse
+	if client.conditional {
+		client.cacheMutex.Lock()
+		if entry, ok := client.cache[request.URL.String()]; ok {
+			cached = &entry
+			request.Header.Set("If-Modified-Since", entry.validFrom)
+		}
+		client.cacheMutex.Unlock()
+	}
+
+	httpResponse, err := client.send(request)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+
+	body, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	if client.onResponse != nil {
+		client.onResponse(httpResponse, body)
+	}
+
+	if httpResponse.StatusCode == http.StatusNotModified && cached != nil {
+		return cached.body, nil
+	}
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		return nil, errors.New(fmt.Sprintf("unexpected status code: %d", httpResponse.StatusCode))
+	}
+	if err := end.checkContentType(httpResponse.Header.Get("Content-Type")); err != nil {
+		return nil, err
+	}
+
+	if client.conditional {
+		validFrom := httpResponse.Header.Get("Last-Modified")
+		if validFrom == "" {
+			validFrom = client.getNow().UTC().Format(http.TimeFormat)
+		}
+
+		client.cacheMutex.Lock()
+		if client.cache == nil {
+			client.cache = make(map[string]cachedResponse)
+		}
+		client.cache[request.URL.String()] = cachedResponse{body: body, validFrom: validFrom}
+		client.cacheMutex.Unlock()
+	}
+	return body, nil
+}
+
+// GetMETAR executes a METARQuery.
+// Please keep in mind that this method only returns an error if the query is invalid, the request itself failed or the
+// server responded with a non-successful (code < 200 || code > 299) status code.
+// The returned METARResponse contains separate fields that contain warnings and errors due to the AWC Text Data Server
+// design.
+func (client *Client) GetMETAR(query *METARQuery) (*METARResponse, error) {
+	return client.GetMETARContext(context.Background(), query)
+}
+
+// GetMETARContext executes a METARQuery just like GetMETAR does, but aborts the request as soon as ctx is done.
+// In that case ctx.Err() is returned, i.e. context.Canceled or context.DeadlineExceeded.
+func (client *Client) GetMETARContext(ctx context.Context, query *METARQuery) (*METARResponse, error) {
+	response, _, err := client.getMETARRaw(ctx, query)
+	return response, err
+}
+
+// GetMETARRaw executes a METARQuery just like GetMETAR does, but additionally returns the unmodified response body the
+// METARResponse was decoded from.
+func (client *Client) GetMETARRaw(query *METARQuery) (*METARResponse, []byte, error) {
+	return client.getMETARRaw(context.Background(), query)
+}
+
+func (client *Client) getMETARRaw(ctx context.Context, query *METARQuery) (*METARResponse, []byte, error) {
+	if err := query.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	end := query.buildEndpoint()
+	body, err := client.fetch(ctx, end)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response := new(METARResponse)
+	if end.format == FormatJSON {
+		err = json.Unmarshal(body, response)
+	} else {
+		err = decodeResponse(body, response)
+	}
+	if err != nil {
+		return nil, body, err
+	}
+
+	if client.checkSchema && end.format == FormatXML {
+		unknown, err := findUnknownElements(body)
+		if err != nil {
+			return nil, body, err
+		}
+		if len(unknown) > 0 {
+			response.Warnings = append(response.Warnings,
+				fmt.Sprintf("unknown element(s): %s", strings.Join(unknown, ", ")))
+		}
+	}
+
+	if client.warningsAsErrors && len(response.Warnings) > 0 {
+		return nil, body, &WarningsError{Warnings: response.Warnings}
+	}
+	if client.errorOnNoData && len(response.METARs) == 0 && len(response.Errors) == 0 {
+		return nil, body, ErrNoData
+	}
+
+	for _, metar := range response.METARs {
+		metar.Converted = metar.convert(client.units)
+	}
+	return response, body, nil
+}
+
+// decodeResponse decodes the <response> element at the beginning of body into response.
+// Anything following that element is ignored, as the server occasionally appends non-XML diagnostics to the document.
+// Documents declaring an encoding other than UTF-8, e.g. ISO-8859-1 as seen from transforming proxies, are converted to
+// UTF-8 first.
+func decodeResponse(body []byte, response interface{}) error {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	decoder.CharsetReader = charset.NewReaderLabel
+	return decoder.Decode(response)
+}
+
+// GetMETARs executes a METARQuery and returns only the fetched METARs.
+// In contrast to GetMETAR, this method also returns an error if the AWC Text Data Server reported any errors.
+// Warnings are ignored unless WarningsAsErrors is enabled; use GetMETAR if you need access to them.
+func (client *Client) GetMETARs(query *METARQuery) ([]*METAR, error) {
+	response, err := client.GetMETAR(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Errors) > 0 {
+		return nil, errors.New(fmt.Sprintf("api error(s): %s", strings.Join(response.Errors, "; ")))
+	}
+	return response.METARs, nil
+}
+
+// GetNearestMETAR executes a METARQuery built using NearestStation and returns the METAR of the station closest to the
+// searched point.
+// If no station reports within the current search radius, the radius is doubled until the maximum of 500 statute miles
+// is reached; an error wrapping ErrNoData is returned if there still is no station. The passed query itself is not
+// modified.
+func (client *Client) GetNearestMETAR(query *METARQuery) (*METAR, error) {
+	if query.radRadius == nil {
+		return nil, errors.New("query does not specify a point to search the nearest station for")
+	}
+
+	current := *query
+	for {
+		metars, err := client.GetMETARs(&current)
+		if err != nil && !errors.Is(err, ErrNoData) {
+			return nil, err
+		}
+
+		var nearest *METAR
+		var nearestDistance float32
+		for _, metar := range metars {
+			distance := metar.DistanceFrom(*current.radLat, *current.radLon)
+			if nearest == nil || distance < nearestDistance {
+				nearest = metar
+				nearestDistance = distance
+			}
+		}
+		if nearest != nil {
+			return nearest, nil
+		}
+
+		if *current.radRadius >= maxRadialDistance {
+			return nil, fmt.Errorf("%w: no station found within %d statute miles", ErrNoData, maxRadialDistance)
+		}
+		radius := keepFloatInRange(*current.radRadius*2, 0, maxRadialDistance)
+		current.RadialDistance(radius, *current.radLat, *current.radLon)
+	}
+}
+
+// Age calculates the time elapsed since the observation of the given METAR according to the clock of the client
+func (client *Client) Age(metar *METAR) (time.Duration, error) {
+	observedAt, err := metar.ObservedAt()
+	if err != nil {
+		return 0, err
+	}
+	return client.getNow().Sub(observedAt), nil
+}
+
+// GetLatestMETAR fetches the most recent METAR of the given station reported within the last 3 hours.
+// An error wrapping ErrNoData is returned if the station did not report any METAR in that time.
+func (client *Client) GetLatestMETAR(station string) (*METAR, error) {
+	metars, err := client.GetMETARs(NewMETARQuery().
+		Station(station).
+		HoursBeforeNow(3).
+		MostRecent(true))
+	if err != nil && !errors.Is(err, ErrNoData) {
+		return nil, err
+	}
+	if len(metars) == 0 {
+		return nil, fmt.Errorf("%w: no METAR reported by station %s within the last 3 hours", ErrNoData, station)
+	}
+	return metars[0], nil
+}
+
+// GetMETARPaged executes a METARQuery built using Between by splitting its timespan into sequential windows of the
+// given size, fetching them one after another and passing every response to callback.
+// This avoids huge responses when fetching long timespans. As the timespans are inclusive, the server returns
+// observations at a window boundary for both adjacent windows; these are removed from the later page (see
+// METARResponse.Dedupe), so every observation is passed to callback once.
+// The execution stops as soon as a request fails, callback returns an error or ctx is done. Empty pages are skipped if
+// ErrorOnNoData is enabled.
+func (client *Client) GetMETARPaged(ctx context.Context, query *METARQuery, window time.Duration,
+	callback func(response *METARResponse) error) error {
+	if query.startTime == nil {
+		return errors.New("paged queries require a timespan specified using Between")
+	}
+	if window <= 0 {
+		return errors.New("window has to be positive")
+	}
+
+	start := time.Unix(*query.startTime, 0)
+	end := time.Unix(*query.endTime, 0)
+
+	page := *query
+	var previous map[metarKey]bool
+	for pageStart := start; !pageStart.After(end); pageStart = pageStart.Add(window) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		pageEnd := pageStart.Add(window)
+		if pageEnd.After(end) {
+			pageEnd = end
+		}
+		page.Between(pageStart, pageEnd)
+
+		response, _, err := client.getMETARRaw(ctx, &page)
+		if err != nil && !errors.Is(err, ErrNoData) {
+			return err
+		}
+		if response != nil {
+			current := make(map[metarKey]bool, len(response.METARs))
+			for _, metar := range response.METARs {
+				current[metar.key()] = true
+			}
+			response = response.filter(func(metar *METAR) bool {
+				return !previous[metar.key()]
+			})
+			previous = current
+
+			if err := callback(response); err != nil {
+				return err
+			}
+		}
+
+		if !pageEnd.Before(end) {
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,573 @@
+package awc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/exec"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testMETARResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<response>
+  <data num_results="1">
+    <METAR>
+      <raw_text>KSFO 011256Z 28012KT 10SM FEW020 15/08 A3002</raw_text>
+      <station_id>KSFO</station_id>
+      <observation_time>2024-05-01T12:56:00Z</observation_time>
+      <flight_category>VFR</flight_category>
+    </METAR>
+  </data>
+</response>`
+
+// newTestServer starts a server answering every request with handler and returns a client using it
+func newTestServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, *Client) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server, new(Client).BaseURL(server.URL)
+}
+
+// serveMETARs answers with testMETARResponse
+func serveMETARs(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/xml")
+	fmt.Fprint(w, testMETARResponse)
+}
+
+func TestInsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(serveMETARs))
+	defer server.Close()
+	query := NewMETARQuery().HoursBeforeNow(1)
+
+	if _, err := new(Client).BaseURL(server.URL).GetMETAR(query); err == nil {
+		t.Error("expected the self-signed certificate to be rejected by default")
+	}
+
+	response, err := new(Client).BaseURL(server.URL).InsecureSkipVerify(true).GetMETAR(query)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(response.METARs) != 1 || response.METARs[0].StationID != "KSFO" {
+		t.Errorf("unexpected METARs: %+v", response.METARs)
+	}
+}
+
+func TestInsecureSkipVerifyDoesNotAffectDefaultClient(t *testing.T) {
+	client := new(Client).InsecureSkipVerify(true)
+	if client.getHTTPClient() == http.DefaultClient {
+		t.Error("expected an own HTTP client")
+	}
+	if new(Client).getHTTPClient() != http.DefaultClient {
+		t.Error("expected http.DefaultClient for the zero value")
+	}
+}
+
+func TestGetHTTPClientConcurrentUse(t *testing.T) {
+	_, client := newTestServer(t, serveMETARs)
+	client.IPVersion(IPVersion4)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetMETAR(NewMETARQuery().HoursBeforeNow(1)); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if first, second := client.getHTTPClient(), client.getHTTPClient(); first != second {
+		t.Error("expected the built HTTP client to be reused")
+	}
+}
+
+func TestNewTransportUsesProxyFromEnvironment(t *testing.T) {
+	for _, client := range []*Client{
+		new(Client).InsecureSkipVerify(true),
+		new(Client).IPVersion(IPVersion6),
+		new(Client).MaxConnsPerHost(4),
+	} {
+		transport := client.newTransport()
+		if transport.Proxy == nil ||
+			reflect.ValueOf(transport.Proxy).Pointer() != reflect.ValueOf(http.ProxyFromEnvironment).Pointer() {
+			t.Errorf("expected the transport to use http.ProxyFromEnvironment")
+		}
+	}
+}
+
+// proxyChildEnv is set when TestOwnTransportHonorsProxyEnvironment runs itself in a child process, as
+// http.ProxyFromEnvironment reads the environment only once per process
+const proxyChildEnv = "AWC_TEST_PROXY_CHILD"
+
+func TestOwnTransportHonorsProxyEnvironment(t *testing.T) {
+	if os.Getenv(proxyChildEnv) == "1" {
+		response, err := new(Client).BaseURL("http://aviationweather.test/httpparam").InsecureSkipVerify(true).
+			GetMETAR(NewMETARQuery().HoursBeforeNow(1))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(response.METARs) != 1 {
+			t.Fatalf("unexpected METARs: %+v", response.METARs)
+		}
+		return
+	}
+
+	var proxied []string
+	var mutex sync.Mutex
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		proxied = append(proxied, r.URL.Host)
+		mutex.Unlock()
+		serveMETARs(w, r)
+	}))
+	defer proxy.Close()
+
+	command := exec.Command(os.Args[0], "-test.run=^TestOwnTransportHonorsProxyEnvironment$")
+	command.Env = append(os.Environ(), proxyChildEnv+"=1", "HTTP_PROXY="+proxy.URL, "http_proxy="+proxy.URL,
+		"NO_PROXY=", "no_proxy=")
+	if output, err := command.CombinedOutput(); err != nil {
+		t.Fatalf("child process failed: %v\n%s", err, output)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if len(proxied) != 1 || proxied[0] != "aviationweather.test" {
+		t.Errorf("expected the request to be sent through the proxy, got %v", proxied)
+	}
+}
+
+func TestCustomHTTPClientProxy(t *testing.T) {
+	var proxied bool
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.Host == "aviationweather.test"
+		serveMETARs(w, r)
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	httpClient := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}
+	_, err := new(Client).BaseURL("http://aviationweather.test/httpparam").HTTPClient(httpClient).
+		GetMETAR(NewMETARQuery().HoursBeforeNow(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !proxied {
+		t.Error("expected the request to be sent through the configured proxy")
+	}
+}
+
+func TestConnectionLimits(t *testing.T) {
+	tests := []struct {
+		client            *Client
+		maxConns, maxIdle int
+	}{
+		{new(Client).InsecureSkipVerify(true), defaultMaxConnsPerHost, defaultMaxIdleConnsPerHost},
+		{new(Client).MaxConnsPerHost(2), 2, defaultMaxIdleConnsPerHost},
+		{new(Client).MaxIdleConnsPerHost(3), defaultMaxConnsPerHost, 3},
+		{new(Client).MaxConnsPerHost(0), defaultMaxConnsPerHost, defaultMaxIdleConnsPerHost},
+		{new(Client).MaxConnsPerHost(-1).MaxIdleConnsPerHost(0), defaultMaxConnsPerHost, defaultMaxIdleConnsPerHost},
+	}
+	for i, test := range tests {
+		httpClient := test.client.getHTTPClient()
+		if httpClient == http.DefaultClient {
+			t.Errorf("test %d: expected an own HTTP client", i)
+			continue
+		}
+		transport := httpClient.Transport.(*http.Transport)
+		if transport.MaxConnsPerHost != test.maxConns || transport.MaxIdleConnsPerHost != test.maxIdle {
+			t.Errorf("test %d: got limits %d/%d; want %d/%d", i, transport.MaxConnsPerHost,
+				transport.MaxIdleConnsPerHost, test.maxConns, test.maxIdle)
+		}
+	}
+}
+
+func TestHeaders(t *testing.T) {
+	var received http.Header
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		serveMETARs(w, r)
+	})
+	headers := http.Header{}
+	headers.Set("X-Api-Key", "secret")
+	headers.Add("X-Trace", "a")
+	headers.Add("X-Trace", "b")
+	headers.Set("Accept", "application/xml")
+	client.Headers(headers)
+	headers.Set("X-Api-Key", "modified")
+
+	if _, err := client.GetMETAR(NewMETARQuery().HoursBeforeNow(1)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := received.Get("X-Api-Key"); got != "secret" {
+		t.Errorf("got X-Api-Key %q; want %q", got, "secret")
+	}
+	if got := received.Values("X-Trace"); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("got X-Trace %q; want %q", got, []string{"a", "b"})
+	}
+	if got := received.Get("Accept"); got != "application/xml" {
+		t.Errorf("got Accept %q; want %q", got, "application/xml")
+	}
+}
+
+func TestDecodeResponseCharsets(t *testing.T) {
+	tests := []struct {
+		encoding string
+		warning  []byte
+		want     string
+	}{
+		{"UTF-8", []byte("Z\xc3\xbcrich"), "Zürich"},
+		{"US-ASCII", []byte("Zurich"), "Zurich"},
+		{"ISO-8859-1", []byte("Z\xfcrich 15\xb0C"), "Zürich 15°C"},
+		{"latin1", []byte("S\xe3o Paulo"), "São Paulo"},
+		{"windows-1252", []byte("\x93quoted\x94 \x80"), "“quoted” €"},
+		{"ISO-8859-15", []byte("\xa4 \xbd"), "€ œ"},
+		{"KOI8-R", []byte("\xed\xcf\xd3\xcb\xd7\xc1"), "Москва"},
+		{"Shift_JIS", []byte("\x93\x8c\x8b\x9e"), "東京"},
+	}
+	for _, test := range tests {
+		body := []byte(`<?xml version="1.0" encoding="` + test.encoding + `"?><response><warnings><warning>` +
+			string(test.warning) + `</warning></warnings></response>`)
+		response := new(METARResponse)
+		if err := decodeResponse(body, response); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.encoding, err)
+			continue
+		}
+		if len(response.Warnings) != 1 || response.Warnings[0] != test.want {
+			t.Errorf("%s: got warnings %q; want %q", test.encoding, response.Warnings, test.want)
+		}
+	}
+
+	body := []byte(`<?xml version="1.0" encoding="x-unknown"?><response></response>`)
+	if err := decodeResponse(body, new(METARResponse)); err == nil {
+		t.Error("expected an error for an unknown encoding")
+	}
+}
+
+func TestGetMETARDecodesLegacyCharsets(t *testing.T) {
+	for _, encoding := range []string{"ISO-8859-1", "windows-1252"} {
+		body := []byte(`<?xml version="1.0" encoding="` + encoding + `"?>
+<response>
+  <warnings><warning>Station Z` + "\xfc" + `rich temporarily unavailable</warning></warnings>
+  <data num_results="1">
+    <METAR>
+      <raw_text>LSZH 011250Z 24008KT 9999 FEW040 15/08 Q1018 NOSIG</raw_text>
+      <station_id>LSZH</station_id>
+      <observation_time>2024-05-01T12:50:00Z</observation_time>
+    </METAR>
+  </data>
+</response>`)
+		_, client := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "text/xml; charset="+encoding)
+			w.Write(body)
+		})
+
+		response, err := client.GetMETAR(NewMETARQuery().HoursBeforeNow(1))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", encoding, err)
+		}
+		if len(response.Warnings) != 1 || response.Warnings[0] != "Station Zürich temporarily unavailable" {
+			t.Errorf("%s: unexpected warnings: %q", encoding, response.Warnings)
+		}
+		if len(response.METARs) != 1 || response.METARs[0].StationID != "LSZH" {
+			t.Errorf("%s: unexpected METARs: %+v", encoding, response.METARs)
+		}
+	}
+}
+
+func TestGetMETARContext(t *testing.T) {
+	var requests int32
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+			serveMETARs(w, r)
+		}
+	})
+	query := NewMETARQuery().HoursBeforeNow(1)
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.GetMETARContext(canceled, query); !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v; want context.Canceled", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 0 {
+		t.Errorf("expected no request for a canceled context, got %d", n)
+	}
+
+	deadline, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.GetMETARContext(deadline, query); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v; want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected the request to be aborted at the deadline, took %s", elapsed)
+	}
+}
+
+func TestClock(t *testing.T) {
+	var ifModifiedSince []string
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		ifModifiedSince = append(ifModifiedSince, r.Header.Get("If-Modified-Since"))
+		serveMETARs(w, r)
+	})
+	now := time.Date(2024, time.May, 1, 13, 26, 0, 0, time.UTC)
+	client.Clock(func() time.Time { return now }).ConditionalRequests(true)
+
+	response, err := client.GetMETAR(NewMETARQuery().Station("KSFO").HoursBeforeNow(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if age, err := client.Age(response.METARs[0]); err != nil || age != 30*time.Minute {
+		t.Errorf("Age() = %s, %v; want 30m0s", age, err)
+	}
+	now = now.Add(time.Hour)
+	if age, err := client.Age(response.METARs[0]); err != nil || age != 90*time.Minute {
+		t.Errorf("Age() = %s, %v; want 1h30m0s", age, err)
+	}
+	if _, err := client.Age(&METAR{ObservationTime: "yesterday"}); err == nil {
+		t.Error("expected an error for an unparseable observation time")
+	}
+
+	if _, err := client.GetMETAR(NewMETARQuery().Station("KSFO").HoursBeforeNow(1)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"", "Wed, 01 May 2024 13:26:00 GMT"}
+	if !reflect.DeepEqual(ifModifiedSince, want) {
+		t.Errorf("got If-Modified-Since %q; want %q", ifModifiedSince, want)
+	}
+
+	metar, err := client.ParseMETAR("KSFO 301456Z 28012KT 10SM FEW020 15/08 A3002")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if metar.ObservationTime != "2024-04-30T14:56:00Z" {
+		t.Errorf("got observation time %q; want it resolved against the clock", metar.ObservationTime)
+	}
+
+	if age, err := response.METARs[0].Age(); err != nil || age < time.Since(now) {
+		t.Errorf("METAR.Age() = %s, %v; want the age according to the system time", age, err)
+	}
+}
+
+func TestConditionalRequests(t *testing.T) {
+	const lastModified = "Wed, 01 May 2024 12:58:00 GMT"
+	type request struct{ station, ifModifiedSince string }
+	var requests []request
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, request{r.URL.Query().Get("stationString"), r.Header.Get("If-Modified-Since")})
+		if r.Header.Get("If-Modified-Since") == lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", lastModified)
+		serveMETARs(w, r)
+	})
+	client.ConditionalRequests(true)
+
+	first, err := client.GetMETAR(NewMETARQuery().Station("KSFO").HoursBeforeNow(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := client.GetMETAR(NewMETARQuery().Station("KSFO").HoursBeforeNow(1))
+	if err != nil {
+		t.Fatalf("unexpected error for a 304 response: %v", err)
+	}
+	if !reflect.DeepEqual(first, second) || len(second.METARs) != 1 {
+		t.Errorf("expected the cached response to be returned, got %+v", second)
+	}
+	if _, err := client.GetMETAR(NewMETARQuery().Station("KOAK").HoursBeforeNow(1)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []request{{"KSFO", ""}, {"KSFO", lastModified}, {"KOAK", ""}}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("got requests %+v; want %+v", requests, want)
+	}
+
+	requests = nil
+	client.ConditionalRequests(false)
+	if _, err := client.GetMETAR(NewMETARQuery().Station("KSFO").HoursBeforeNow(1)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(requests) != 1 || requests[0].ifModifiedSince != "" {
+		t.Errorf("expected no If-Modified-Since header if disabled, got %+v", requests)
+	}
+}
+
+func TestConditionalRequestsWithoutCache(t *testing.T) {
+	_, client := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	})
+	if _, err := client.ConditionalRequests(true).GetMETAR(NewMETARQuery().HoursBeforeNow(1)); err == nil {
+		t.Error("expected an error for a 304 response without a cached response")
+	}
+}
+
+// serveMETARsBetween answers with a METAR of KSFO for every half hour of 2024-05-01 between 12:00 and 14:00 UTC that
+// lies within the requested timespan, including its boundaries
+func serveMETARsBetween(w http.ResponseWriter, r *http.Request) {
+	start, _ := strconv.ParseInt(r.URL.Query().Get("startTime"), 10, 64)
+	end, _ := strconv.ParseInt(r.URL.Query().Get("endTime"), 10, 64)
+
+	w.Header().Set("Content-Type", "text/xml")
+	fmt.Fprint(w, `<response><data>`)
+	for i := 0; i <= 4; i++ {
+		observedAt := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC).Add(time.Duration(i) * 30 * time.Minute)
+		if observedAt.Unix() < start || observedAt.Unix() > end {
+			continue
+		}
+		fmt.Fprintf(w, `<METAR><raw_text>KSFO %s 28012KT 10SM FEW020 15/08 A3002</raw_text>`+
+			`<station_id>KSFO</station_id><observation_time>%s</observation_time></METAR>`,
+			observedAt.Format("021504Z"), observedAt.Format(time.RFC3339))
+	}
+	fmt.Fprint(w, `</data></response>`)
+}
+
+func TestGetMETARPaged(t *testing.T) {
+	var windows []string
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		windows = append(windows, r.URL.Query().Get("startTime")+"-"+r.URL.Query().Get("endTime"))
+		serveMETARsBetween(w, r)
+	})
+	start := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	query := NewMETARQuery().Station("KSFO").Between(start, start.Add(2*time.Hour))
+
+	var pages [][]string
+	err := client.GetMETARPaged(context.Background(), query, time.Hour, func(response *METARResponse) error {
+		var times []string
+		for _, metar := range response.METARs {
+			times = append(times, metar.ObservationTime[11:16])
+		}
+		pages = append(pages, times)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantPages := [][]string{{"12:00", "12:30", "13:00"}, {"13:30", "14:00"}}
+	if !reflect.DeepEqual(pages, wantPages) {
+		t.Errorf("got pages %v; want %v", pages, wantPages)
+	}
+	wantWindows := []string{"1714564800-1714568400", "1714568400-1714572000"}
+	if !reflect.DeepEqual(windows, wantWindows) {
+		t.Errorf("got windows %v; want %v", windows, wantWindows)
+	}
+
+	windows = nil
+	errStop := errors.New("stop")
+	err = client.GetMETARPaged(context.Background(), query, 30*time.Minute, func(*METARResponse) error {
+		return errStop
+	})
+	if !errors.Is(err, errStop) || len(windows) != 1 {
+		t.Errorf("expected the callback error to stop after 1 page, got %v after %d", err, len(windows))
+	}
+
+	windows = nil
+	ctx, cancel := context.WithCancel(context.Background())
+	err = client.GetMETARPaged(ctx, query, 30*time.Minute, func(*METARResponse) error {
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || len(windows) != 1 {
+		t.Errorf("expected the cancellation to stop after 1 page, got %v after %d", err, len(windows))
+	}
+
+	noop := func(*METARResponse) error { return nil }
+	err = client.GetMETARPaged(context.Background(), NewMETARQuery().HoursBeforeNow(1), time.Hour, noop)
+	if err == nil {
+		t.Error("expected an error for a query without Between")
+	}
+	if err := client.GetMETARPaged(context.Background(), query, 0, noop); err == nil {
+		t.Error("expected an error for a window of 0")
+	}
+}
+
+func TestTrailingGarbage(t *testing.T) {
+	tests := []struct {
+		name, suffix string
+	}{
+		{"diagnostics", "\n<!-- 12 ms -->\nOracle error: ORA-01013: user requested cancel of current operation\n"},
+		{"html", "\n<html><body>Proxy notice</body></html>"},
+		{"broken tag", "\n</response><"},
+		{"binary", "\n\x00\x01\x02"},
+	}
+	for _, test := range tests {
+		body := testMETARResponse + test.suffix
+		_, client := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "text/xml")
+			fmt.Fprint(w, body)
+		})
+		response, err := client.GetMETAR(NewMETARQuery().HoursBeforeNow(1))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if len(response.METARs) != 1 || response.METARs[0].StationID != "KSFO" {
+			t.Errorf("%s: unexpected METARs: %+v", test.name, response.METARs)
+		}
+	}
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		fmt.Fprint(w, testMETARResponse[:len(testMETARResponse)-40])
+	})
+	if _, err := client.GetMETAR(NewMETARQuery().HoursBeforeNow(1)); err == nil {
+		t.Error("expected an error for a truncated document")
+	}
+}
+
+func TestGetNearestMETAR(t *testing.T) {
+	var radii []string
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		// url.ParseQuery rejects the semicolon of the radialDistance parameter
+		radius := strings.SplitN(strings.SplitN(r.URL.RawQuery, "radialDistance=", 2)[1], ";", 2)[0]
+		radii = append(radii, radius)
+		w.Header().Set("Content-Type", "text/xml")
+		if radius != "100.000000" {
+			fmt.Fprint(w, `<response><data num_results="0" /></response>`)
+			return
+		}
+		fmt.Fprint(w, `<response><data num_results="2">
+<METAR><station_id>KOAK</station_id><latitude>37.72</latitude><longitude>-122.22</longitude></METAR>
+<METAR><station_id>KSFO</station_id><latitude>37.62</latitude><longitude>-122.37</longitude></METAR>
+</data></response>`)
+	})
+
+	metar, err := client.GetNearestMETAR(NewMETARQuery().NearestStation(37.6, -122.4).HoursBeforeNow(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if metar.StationID != "KSFO" {
+		t.Errorf("got %s; want the nearest station KSFO", metar.StationID)
+	}
+	if want := []string{"25.000000", "50.000000", "100.000000"}; !reflect.DeepEqual(radii, want) {
+		t.Errorf("got radii %v; want %v", radii, want)
+	}
+
+	radii = nil
+	_, err = client.GetNearestMETAR(NewMETARQuery().RadialDistance(120, 0, 0).HoursBeforeNow(1))
+	if !errors.Is(err, ErrNoData) {
+		t.Errorf("expected ErrNoData, got %v", err)
+	}
+	if want := []string{"120.000000", "240.000000", "480.000000", "500.000000"}; !reflect.DeepEqual(radii, want) {
+		t.Errorf("got radii %v; want %v", radii, want)
+	}
+
+	if _, err := client.GetNearestMETAR(NewMETARQuery().HoursBeforeNow(1)); err == nil {
+		t.Error("expected an error for a query without a point")
+	}
+}
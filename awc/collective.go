@@ -0,0 +1,120 @@
+package awc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ParseCollective parses the METARs contained in a WMO collective bulletin like
+//
+//	SAUS80 KWBC 011300
+//	METAR
+//	KSFO 011256Z 28012KT 10SM FEW020 15/08 A3002
+//	     RMK AO2 SLP165=
+//	KLAX 011253Z 25008KT 10SM CLR 18/10 A2998=
+//
+// using ParseMETAR.
+// The header lines (sequence number, WMO abbreviated heading and AWIPS identifier) preceding the first report are
+// skipped, just like the 'NNNN' and '$$' footers and transmission control characters. A 'METAR' or 'SPECI' line
+// specifies the type of the following reports. Reports are terminated by '='; reports wrapped onto multiple lines are
+// joined. If the bulletin contains no terminators at all, every line not indented starts a new report instead.
+// Reports consisting of a station ID and the 'NIL' modifier only are skipped.
+// An error is returned if any report fails to parse.
+// Use ParseCollectiveAt or Client.ParseCollective to resolve the observation times against another clock.
+func ParseCollective(raw string) ([]*METAR, error) {
+	return ParseCollectiveAt(raw, time.Now())
+}
+
+// ParseCollective parses the METARs of a WMO collective bulletin just like the package-level ParseCollective does, but
+// resolves the observation times against the clock of the client (see Clock)
+func (client *Client) ParseCollective(raw string) ([]*METAR, error) {
+	return ParseCollectiveAt(raw, client.getNow())
+}
+
+// ParseCollectiveAt parses the METARs of a WMO collective bulletin just like ParseCollective does, but resolves the
+// observation times against now; please refer to ParseMETARAt
+func ParseCollectiveAt(raw string, now time.Time) ([]*METAR, error) {
+	raw = strings.Map(func(char rune) rune {
+		switch char {
+		case '\r', '\x01', '\x03':
+			return -1
+		}
+		return char
+	}, raw)
+	terminated := strings.Contains(raw, "=")
+
+	var reports []string
+	reportType := ""
+	current := ""
+	flush := func() {
+		for _, report := range strings.Split(current, "=") {
+			if report = strings.TrimSpace(report); report == "" {
+				continue
+			}
+			if reportType != "" && !strings.HasPrefix(report, "METAR ") && !strings.HasPrefix(report, "SPECI ") {
+				report = reportType + " " + report
+			}
+			reports = append(reports, report)
+		}
+		current = ""
+	}
+
+	inHeader := true
+	for _, line := range strings.Split(raw, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			continue
+		case trimmed == "NNNN" || trimmed == "$$":
+			flush()
+			continue
+		case trimmed == "METAR" || trimmed == "SPECI":
+			flush()
+			reportType = trimmed
+			inHeader = false
+			continue
+		case inHeader && isCollectiveHeader(trimmed):
+			continue
+		}
+		inHeader = false
+
+		if !terminated && line == strings.TrimLeft(line, " \t") {
+			flush()
+		}
+		current += " " + trimmed
+	}
+	flush()
+
+	var metars []*METAR
+	for i, report := range reports {
+		groups := strings.Fields(report)
+		if groups[0] == "METAR" || groups[0] == "SPECI" {
+			groups = groups[1:]
+		}
+		if len(groups) == 2 && groups[1] == "NIL" {
+			continue
+		}
+
+		metar, err := ParseMETARAt(report, now)
+		if err != nil {
+			return nil, fmt.Errorf("report %d of the bulletin: %w", i+1, err)
+		}
+		metars = append(metars, metar)
+	}
+	return metars, nil
+}
+
+// isCollectiveHeader reports whether the line is a header line of a collective bulletin, i.e. a sequence number like
+// '000', a WMO abbreviated heading like 'SAUS80 KWBC 011300 RRA' or an AWIPS identifier like 'MTRSFO'
+func isCollectiveHeader(line string) bool {
+	groups := strings.Fields(line)
+	switch {
+	case len(groups) == 1:
+		return isDigits(line) || !IsValidStationID(line)
+	case len(groups) == 3 || len(groups) == 4:
+		return len(groups[0]) == 6 && isDigits(groups[0][4:]) && len(groups[1]) == 4 && len(groups[2]) == 6 &&
+			isDigits(groups[2])
+	}
+	return false
+}
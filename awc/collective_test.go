@@ -0,0 +1,92 @@
+package awc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testBulletin is a collective bulletin as distributed by the NWS, including the transmission control characters
+const testBulletin = "\x01\r\r\n" +
+	"000 \r\r\n" +
+	"SAUS70 KWBC 011300 RRA\r\r\n" +
+	"MTRSFO\r\r\n" +
+	"METAR\r\r\n" +
+	"KSFO 011256Z 28012KT 10SM FEW020 15/08 A3002 RMK AO2 SLP165\r\r\n" +
+	"     T01500083=\r\r\n" +
+	"KOAK 011253Z 27008KT 10SM SCT015 BKN025 14/09 A3001 RMK AO2\r\r\n" +
+	"     SLP162 T01440089=\r\r\n" +
+	"KSJC 011253Z VRB03KT 10SM CLR 16/07 A3000 RMK AO2 SLP160=\r\r\n" +
+	"KHAF NIL=\r\r\n" +
+	"SPECI KSQL 011308Z 00000KT 1 1/2SM BR OVC004 12/11 A3002 RMK AO2=\r\r\n" +
+	"\r\r\n" +
+	"$$\r\r\n" +
+	"NNNN\r\r\n\x03"
+
+func TestParseCollectiveAt(t *testing.T) {
+	metars, err := ParseCollectiveAt(testBulletin, testNow)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var stations, types []string
+	for _, metar := range metars {
+		stations = append(stations, metar.StationID)
+		types = append(types, metar.METARType)
+	}
+	if want := []string{"KSFO", "KOAK", "KSJC", "KSQL"}; !reflect.DeepEqual(stations, want) {
+		t.Fatalf("got stations %v; want %v", stations, want)
+	}
+	if want := []string{"METAR", "METAR", "METAR", "SPECI"}; !reflect.DeepEqual(types, want) {
+		t.Errorf("got types %v; want %v", types, want)
+	}
+
+	oak := metars[1]
+	if want := "KOAK 011253Z 27008KT 10SM SCT015 BKN025 14/09 A3001 RMK AO2 SLP162 T01440089"; oak.RawText != want {
+		t.Errorf("wrapped report not joined: got %q; want %q", oak.RawText, want)
+	}
+	if oak.AirTempC != 14.4 || oak.DewPointC != 8.9 || oak.ObservationTime != "2024-05-01T12:53:00Z" {
+		t.Errorf("unexpected values of the wrapped report: %+v", oak)
+	}
+	if metars[3].FlightCategory != FlightCategoryLIFR {
+		t.Errorf("got flight category %q; want LIFR", metars[3].FlightCategory)
+	}
+}
+
+func TestParseCollectiveAtWithoutTerminators(t *testing.T) {
+	bulletin := strings.Join([]string{
+		"SAUS80 KWBC 011300",
+		"METAR",
+		"KSFO 011256Z 28012KT 10SM FEW020 15/08 A3002",
+		"     RMK AO2 SLP165",
+		"KOAK 011253Z 27008KT 10SM SCT015 14/09 A3001",
+	}, "\n")
+	metars, err := ParseCollectiveAt(bulletin, testNow)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(metars) != 2 || metars[0].RawText != "KSFO 011256Z 28012KT 10SM FEW020 15/08 A3002 RMK AO2 SLP165" ||
+		metars[1].StationID != "KOAK" {
+		t.Errorf("unexpected METARs: %+v", metars)
+	}
+}
+
+func TestParseCollectiveAtInvalidReport(t *testing.T) {
+	if _, err := ParseCollectiveAt("SAUS80 KWBC 011300\nKSFO 28012KT=\n", testNow); err == nil {
+		t.Error("expected an error for a report without observation time")
+	}
+}
+
+func TestClientParseCollectiveUsesClock(t *testing.T) {
+	client := new(Client).Clock(func() time.Time {
+		return time.Date(2023, time.February, 1, 14, 0, 0, 0, time.UTC)
+	})
+	metars, err := client.ParseCollective(testBulletin)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if metars[0].ObservationTime != "2023-02-01T12:56:00Z" {
+		t.Errorf("got observation time %s", metars[0].ObservationTime)
+	}
+}
@@ -0,0 +1,416 @@
+package awc
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// csvColumn maps a single column of the CSV format to the respective METAR field
+type csvColumn struct {
+	name string
+	get  func(metar *METAR) string
+	set  func(metar *METAR, value string) error
+}
+
+// csvString creates a column of a string field
+func csvString(name string, field func(metar *METAR) *string) csvColumn {
+	return csvColumn{
+		name: name,
+		get: func(metar *METAR) string {
+			return *field(metar)
+		},
+		set: func(metar *METAR, value string) error {
+			*field(metar) = value
+			return nil
+		},
+	}
+}
+
+// csvFloat creates a column of a float field.
+// The cell is left empty unless present returns true; if present is nil, zero values are considered missing.
+func csvFloat(name string, field func(metar *METAR) *float32, present func(metar *METAR) bool) csvColumn {
+	return csvColumn{
+		name: name,
+		get: func(metar *METAR) string {
+			value := *field(metar)
+			if (present == nil && value == 0) || (present != nil && !present(metar)) {
+				return ""
+			}
+			return strconv.FormatFloat(float64(value), 'f', -1, 32)
+		},
+		set: func(metar *METAR, value string) error {
+			parsed, err := strconv.ParseFloat(value, 32)
+			if err != nil {
+				return err
+			}
+			*field(metar) = float32(parsed)
+			return nil
+		},
+	}
+}
+
+// csvInt creates a column of an integer field.
+// The cell is left empty unless present returns true; if present is nil, zero values are considered missing.
+func csvInt(name string, field func(metar *METAR) *int, present func(metar *METAR) bool) csvColumn {
+	return csvColumn{
+		name: name,
+		get: func(metar *METAR) string {
+			value := *field(metar)
+			if (present == nil && value == 0) || (present != nil && !present(metar)) {
+				return ""
+			}
+			return strconv.Itoa(value)
+		},
+		set: func(metar *METAR, value string) error {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return err
+			}
+			*field(metar) = parsed
+			return nil
+		},
+	}
+}
+
+// csvBool creates a column of a boolean field, which is written as "TRUE" if set and left empty otherwise
+func csvBool(name string, field func(metar *METAR) *bool) csvColumn {
+	return csvColumn{
+		name: name,
+		get: func(metar *METAR) string {
+			if *field(metar) {
+				return "TRUE"
+			}
+			return ""
+		},
+		set: func(metar *METAR, value string) error {
+			parsed, err := strconv.ParseBool(strings.ToLower(value))
+			if err != nil {
+				return err
+			}
+			*field(metar) = parsed
+			return nil
+		},
+	}
+}
+
+func hasAirTemp(metar *METAR) bool {
+	temperature, _ := metar.hasTemperatures()
+	return temperature
+}
+
+func hasDewPoint(metar *METAR) bool {
+	_, dewPoint := metar.hasTemperatures()
+	return dewPoint
+}
+
+func hasWind(metar *METAR) bool {
+	return !metar.IsWindMissing()
+}
+
+// metarCSVColumns contains all columns of the CSV format except the repeated sky condition columns
+var metarCSVColumns = []csvColumn{
+	csvString("raw_text", func(metar *METAR) *string { return &metar.RawText }),
+	csvString("station_id", func(metar *METAR) *string { return &metar.StationID }),
+	csvString("observation_time", func(metar *METAR) *string { return &metar.ObservationTime }),
+	csvFloat("latitude", func(metar *METAR) *float32 { return &metar.Latitude }, nil),
+	csvFloat("longitude", func(metar *METAR) *float32 { return &metar.Longitude }, nil),
+	csvFloat("temp_c", func(metar *METAR) *float32 { return &metar.AirTempC }, hasAirTemp),
+	csvFloat("dewpoint_c", func(metar *METAR) *float32 { return &metar.DewPointC }, hasDewPoint),
+	csvInt("wind_dir_degrees", func(metar *METAR) *int { return &metar.WindDirDegrees }, hasWind),
+	csvInt("wind_speed_kt", func(metar *METAR) *int { return &metar.WindSpeedKT }, hasWind),
+	csvInt("wind_gust_kt", func(metar *METAR) *int { return &metar.WindGustKT }, nil),
+	csvFloat("visibility_statute_mi", func(metar *METAR) *float32 { return &metar.VisibilityStatuteMI },
+		(*METAR).hasVisibility),
+	csvFloat("altim_in_hg", func(metar *METAR) *float32 { return &metar.AltimeterInHG }, nil),
+	csvFloat("sea_level_pressure_mb", func(metar *METAR) *float32 { return &metar.SeaLevelPressureMB }, nil),
+	csvBool("corrected", func(metar *METAR) *bool { return &metar.QualityControlFlags.Corrected }),
+	csvBool("auto", func(metar *METAR) *bool { return &metar.QualityControlFlags.Auto }),
+	csvBool("auto_station", func(metar *METAR) *bool { return &metar.QualityControlFlags.AutoStation }),
+	csvBool("maintenance_indicator_on", func(metar *METAR) *bool {
+		return &metar.QualityControlFlags.MaintenanceIndicator
+	}),
+	csvBool("no_signal", func(metar *METAR) *bool { return &metar.QualityControlFlags.NoSignal }),
+	csvBool("lightning_sensor_off", func(metar *METAR) *bool {
+		return &metar.QualityControlFlags.LightningSensorOff
+	}),
+	csvBool("freezing_rain_sensor_off", func(metar *METAR) *bool {
+		return &metar.QualityControlFlags.FreezingRainSensorOff
+	}),
+	csvBool("present_weather_sensor_off", func(metar *METAR) *bool {
+		return &metar.QualityControlFlags.PresentWeatherSensorOff
+	}),
+	csvString("wx_string", func(metar *METAR) *string { return &metar.WXString }),
+	csvString("flight_category", func(metar *METAR) *string { return &metar.FlightCategory }),
+	csvFloat("three_hr_pressure_tendency_mb", func(metar *METAR) *float32 {
+		return &metar.ThreeHRPressureTendencyMB
+	}, nil),
+	csvFloat("maxT_c", func(metar *METAR) *float32 { return &metar.MaxAirTemp6HC }, nil),
+	csvFloat("minT_c", func(metar *METAR) *float32 { return &metar.MinAirTemp6HC }, nil),
+	csvFloat("maxT24hr_c", func(metar *METAR) *float32 { return &metar.MaxAirTemp24HC }, nil),
+	csvFloat("minT24hr_c", func(metar *METAR) *float32 { return &metar.MinAirTemp24HC }, nil),
+	csvFloat("precip_in", func(metar *METAR) *float32 { return &metar.PrecipitationIN }, nil),
+	csvFloat("pcp3hr_in", func(metar *METAR) *float32 { return &metar.Precipitation3HIN }, nil),
+	csvFloat("pcp6hr_in", func(metar *METAR) *float32 { return &metar.Precipitation6HIN }, nil),
+	csvFloat("pcp24hr_in", func(metar *METAR) *float32 { return &metar.Precipitation24HIN }, nil),
+	csvFloat("snow_in", func(metar *METAR) *float32 { return &metar.SnowDepthIN }, nil),
+	csvInt("vert_vis_ft", func(metar *METAR) *int { return &metar.VerticalVisibilityFT }, nil),
+	csvString("metar_type", func(metar *METAR) *string { return &metar.METARType }),
+	csvFloat("elevation_m", func(metar *METAR) *float32 { return &metar.ElevationM }, nil),
+}
+
+// qualityControlCSVColumns contains the columns written for the quality_control_flags field
+var qualityControlCSVColumns = []string{
+	"corrected",
+	"auto",
+	"auto_station",
+	"maintenance_indicator_on",
+	"no_signal",
+	"lightning_sensor_off",
+	"freezing_rain_sensor_off",
+	"present_weather_sensor_off",
+}
+
+// csvSkyConditions is the amount of sky_cover/cloud_base_ft_agl column pairs written for the sky_condition field,
+// matching the CSV format of the data server
+const csvSkyConditions = 4
+
+// defaultCSVFields contains the fields written by WriteCSV if no fields are specified
+var defaultCSVFields = []string{
+	"raw_text",
+	"station_id",
+	"observation_time",
+	"latitude",
+	"longitude",
+	"temp_c",
+	"dewpoint_c",
+	"wind_dir_degrees",
+	"wind_speed_kt",
+	"wind_gust_kt",
+	"visibility_statute_mi",
+	"altim_in_hg",
+	"wx_string",
+	"sky_condition",
+	"flight_category",
+	"elevation_m",
+}
+
+// csvColumnsOf returns the columns a field expands to
+func csvColumnsOf(field string) ([]csvColumn, bool) {
+	switch field {
+	case "quality_control_flags":
+		var columns []csvColumn
+		for _, column := range metarCSVColumns {
+			if containsString(qualityControlCSVColumns, column.name) {
+				columns = append(columns, column)
+			}
+		}
+		return columns, true
+	case "sky_condition":
+		var columns []csvColumn
+		for i := 0; i < csvSkyConditions; i++ {
+			index := i
+			columns = append(columns, csvColumn{name: "sky_cover", get: func(metar *METAR) string {
+				if index >= len(metar.SkyConditions) {
+					return ""
+				}
+				return metar.SkyConditions[index].SkyCover
+			}}, csvColumn{name: "cloud_base_ft_agl", get: func(metar *METAR) string {
+				if index >= len(metar.SkyConditions) || metar.SkyConditions[index].CloudBaseFTAGL == 0 {
+					return ""
+				}
+				return strconv.Itoa(metar.SkyConditions[index].CloudBaseFTAGL)
+			}})
+		}
+		return columns, true
+	}
+
+	for _, column := range metarCSVColumns {
+		if column.name == field {
+			return []csvColumn{column}, true
+		}
+	}
+	return nil, false
+}
+
+// csvRowDecoder decodes the rows of a METAR CSV document based on its header row
+type csvRowDecoder struct {
+	header []string
+}
+
+func (decoder *csvRowDecoder) decode(row []string) (*METAR, error) {
+	metar := new(METAR)
+	for i, value := range row {
+		if i >= len(decoder.header) || value == "" {
+			continue
+		}
+
+		name := decoder.header[i]
+		switch name {
+		case "sky_cover":
+			metar.SkyConditions = append(metar.SkyConditions, METARSkyCondition{SkyCover: value})
+			continue
+		case "cloud_base_ft_agl":
+			if len(metar.SkyConditions) == 0 {
+				continue
+			}
+			base, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("column %s: %w", name, err)
+			}
+			metar.SkyConditions[len(metar.SkyConditions)-1].CloudBaseFTAGL = base
+			continue
+		}
+
+		for _, column := range metarCSVColumns {
+			if column.name == name {
+				if err := column.set(metar, value); err != nil {
+					return nil, fmt.Errorf("column %s: %w", name, err)
+				}
+				break
+			}
+		}
+	}
+	return metar, nil
+}
+
+// readCSVPreamble consumes the lines preceding the header row of a METAR CSV document.
+// The preamble reports the amount of errors (e.g. "1 errors") followed by the error messages, the amount of warnings
+// and further information like the processing time. An error is returned if the server reported any errors.
+func readCSVPreamble(reader *bufio.Reader) error {
+	var errorMessages []string
+	collectErrors := false
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			if err == io.EOF {
+				return errors.New("unexpected end of CSV document")
+			}
+			return err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		switch {
+		case strings.HasSuffix(line, " results"):
+			if len(errorMessages) > 0 {
+				return errors.New(fmt.Sprintf("api error(s): %s", strings.Join(errorMessages, "; ")))
+			}
+			return nil
+		case strings.HasSuffix(line, " errors"):
+			collectErrors = line != "No errors"
+		case strings.HasSuffix(line, " warnings"):
+			collectErrors = false
+		case collectErrors:
+			errorMessages = append(errorMessages, line)
+		}
+
+		if err == io.EOF {
+			if len(errorMessages) > 0 {
+				return errors.New(fmt.Sprintf("api error(s): %s", strings.Join(errorMessages, "; ")))
+			}
+			return errors.New("unexpected end of CSV document")
+		}
+	}
+}
+
+// StreamMETARCSV executes a METARQuery requesting the CSV format and decodes the response incrementally, calling
+// callback with every METAR as soon as its row was read.
+// In contrast to GetMETAR, the response is never held in memory as a whole, which keeps the memory usage flat for huge
+// responses. The execution stops as soon as a row can not be decoded, callback returns an error or ctx is done. Server
+// errors are returned as an error as well.
+func (client *Client) StreamMETARCSV(ctx context.Context, query *METARQuery, callback func(metar *METAR) error) error {
+	if err := query.validate(); err != nil {
+		return err
+	}
+
+	return client.stream(ctx, query.buildEndpointFrom(endpointMETARCSV), func(body io.Reader) error {
+		reader := bufio.NewReader(body)
+		if err := readCSVPreamble(reader); err != nil {
+			return err
+		}
+
+		csvReader := csv.NewReader(reader)
+		csvReader.FieldsPerRecord = -1
+		csvReader.ReuseRecord = true
+
+		header, err := csvReader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		decoder := &csvRowDecoder{header: append([]string(nil), header...)}
+
+		for {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			row, err := csvReader.Read()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			metar, err := decoder.decode(row)
+			if err != nil {
+				return err
+			}
+			metar.Converted = metar.convert(client.units)
+			if err := callback(metar); err != nil {
+				return err
+			}
+		}
+	})
+}
+
+// WriteCSV writes the METARs of the response as CSV to w, starting with a header row.
+// fields specifies the columns using the same names as METARQuery.Fields; the quality_control_flags field expands to
+// the columns of the individual flags and sky_condition to four sky_cover/cloud_base_ft_agl column pairs, matching the
+// CSV format of the data server. If no fields are specified, a default set including the raw text, station,
+// coordinates, temperatures, wind, visibility, altimeter, weather, sky conditions, flight category and elevation is
+// written.
+// Missing values are written as empty cells; just like in the XML format, numeric fields that are 0 are considered
+// missing, except for the temperatures, wind and visibility which are checked using the raw text.
+func (response *METARResponse) WriteCSV(w io.Writer, fields ...string) error {
+	if len(fields) == 0 {
+		fields = defaultCSVFields
+	}
+
+	var columns []csvColumn
+	for _, field := range fields {
+		fieldColumns, ok := csvColumnsOf(field)
+		if !ok {
+			return errors.New(fmt.Sprintf("unknown CSV field: %s", field))
+		}
+		columns = append(columns, fieldColumns...)
+	}
+
+	writer := csv.NewWriter(w)
+	row := make([]string, len(columns))
+	for i, column := range columns {
+		row[i] = column.name
+	}
+	if err := writer.Write(row); err != nil {
+		return err
+	}
+
+	for _, metar := range response.METARs {
+		for i, column := range columns {
+			row[i] = column.get(metar)
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
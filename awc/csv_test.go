@@ -0,0 +1,154 @@
+package awc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testCSVPreamble = "No errors\nNo warnings\n11 ms\ndata source=metars\n2 results\n"
+
+// serveCSV answers every request with the given CSV document
+func serveCSV(document string) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		fmt.Fprint(w, document)
+	}
+}
+
+// streamMETARs collects the METARs streamed by StreamMETARCSV
+func streamMETARs(client *Client) ([]*METAR, error) {
+	var metars []*METAR
+	err := client.StreamMETARCSV(context.Background(), NewMETARQuery().HoursBeforeNow(1), func(metar *METAR) error {
+		metars = append(metars, metar)
+		return nil
+	})
+	return metars, err
+}
+
+func TestWriteCSVRoundTrip(t *testing.T) {
+	response := &METARResponse{METARs: []*METAR{
+		{
+			RawText:         "KSFO 011256Z 28012G20KT 10SM -RA FEW020 BKN045 15/08 A3002",
+			StationID:       "KSFO",
+			ObservationTime: "2024-05-01T12:56:00Z",
+			Latitude:        37.62, Longitude: -122.37,
+			AirTempC: 15, DewPointC: 8,
+			WindDirDegrees: 280, WindSpeedKT: 12, WindGustKT: 20,
+			VisibilityStatuteMI: 10,
+			AltimeterInHG:       30.02,
+			WXString:            "-RA",
+			SkyConditions:       []METARSkyCondition{{"FEW", 2000}, {"BKN", 4500}},
+			FlightCategory:      FlightCategoryVFR,
+			ElevationM:          3,
+		},
+		{
+			RawText:         "KORD 011251Z 00000KT 1/4SM FG VV002 00/M01 A2992",
+			StationID:       "KORD",
+			ObservationTime: "2024-05-01T12:51:00Z",
+			Latitude:        41.98, Longitude: -87.93,
+			DewPointC:           -1,
+			VisibilityStatuteMI: 0.25,
+			AltimeterInHG:       29.92,
+			WXString:            "FG",
+			SkyConditions:       []METARSkyCondition{{"OVX", 200}},
+			FlightCategory:      FlightCategoryLIFR,
+			ElevationM:          202,
+		},
+	}}
+
+	var buffer bytes.Buffer
+	if err := response.WriteCSV(&buffer); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, client := newTestServer(t, serveCSV(testCSVPreamble+buffer.String()))
+
+	metars, err := streamMETARs(client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(metars, response.METARs) {
+		t.Errorf("round trip failed:\ngot  %+v\nwant %+v\nCSV:\n%s", metars, response.METARs, buffer.String())
+	}
+}
+
+func TestWriteCSVFields(t *testing.T) {
+	response := &METARResponse{METARs: []*METAR{{
+		RawText:             `KSFO 011256Z AUTO 28012KT 10SM CLR 15/08 A3002 RMK "AO2"`,
+		StationID:           "KSFO",
+		QualityControlFlags: METARQualityControlFlags{Auto: true},
+	}}}
+
+	var buffer bytes.Buffer
+	err := response.WriteCSV(&buffer, "station_id", "raw_text", "wind_gust_kt", "quality_control_flags")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "station_id,raw_text,wind_gust_kt,corrected,auto,auto_station,maintenance_indicator_on,no_signal," +
+		"lightning_sensor_off,freezing_rain_sensor_off,present_weather_sensor_off\n" +
+		`KSFO,"KSFO 011256Z AUTO 28012KT 10SM CLR 15/08 A3002 RMK ""AO2""",,,TRUE,,,,,,` + "\n"
+	if buffer.String() != want {
+		t.Errorf("got CSV\n%s\nwant\n%s", buffer.String(), want)
+	}
+
+	if err := response.WriteCSV(&buffer, "bogus"); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+}
+
+func TestStreamMETARCSV(t *testing.T) {
+	const header = "raw_text,station_id,observation_time,temp_c,sky_cover,cloud_base_ft_agl,sky_cover," +
+		"cloud_base_ft_agl\n"
+	const rows = "KSFO 011256Z 28012KT 10SM FEW020 15/08 A3002,KSFO,2024-05-01T12:56:00Z,15,FEW,2000,,\n" +
+		"KOAK 011253Z 27008KT 10SM SKC 14/09 A3001,KOAK,2024-05-01T12:53:00Z,14,SKC,,,\n"
+
+	tests := []struct {
+		name, document string
+		stations       []string
+		wantErr        string
+	}{
+		{"complete", testCSVPreamble + header + rows, []string{"KSFO", "KOAK"}, ""},
+		{"short last row", testCSVPreamble + header + rows + "KSJC 011253Z 31006KT,KSJC\n",
+			[]string{"KSFO", "KOAK", "KSJC"}, ""},
+		{"truncated last row", testCSVPreamble + header + rows + `"KSJC 011253Z 31006KT`,
+			[]string{"KSFO", "KOAK"}, "extraneous or missing"},
+		{"invalid value", testCSVPreamble + header + "KSJC 011253Z,KSJC,,warm\n", nil, "column temp_c"},
+		{"no results", "No errors\nNo warnings\n3 ms\ndata source=metars\n0 results\n", nil, ""},
+		{"api errors", "1 errors\nQuery must be constrained by time\nNo warnings\n3 ms\ndata source=metars\n" +
+			"0 results\n", nil, "api error(s): Query must be constrained by time"},
+		{"truncated preamble", "No errors\nNo warnings\n", nil, "unexpected end of CSV document"},
+	}
+	for _, test := range tests {
+		_, client := newTestServer(t, serveCSV(test.document))
+		metars, err := streamMETARs(client)
+		if (err == nil) != (test.wantErr == "") || (err != nil && !strings.Contains(err.Error(), test.wantErr)) {
+			t.Errorf("%s: got error %v; want %q", test.name, err, test.wantErr)
+		}
+		var stations []string
+		for _, metar := range metars {
+			stations = append(stations, metar.StationID)
+		}
+		if !reflect.DeepEqual(stations, test.stations) {
+			t.Errorf("%s: got METARs of %v; want %v", test.name, stations, test.stations)
+		}
+	}
+
+	_, client := newTestServer(t, serveCSV(testCSVPreamble+header+rows))
+	var metars []*METAR
+	errStop := errors.New("stop")
+	err := client.StreamMETARCSV(context.Background(), NewMETARQuery().HoursBeforeNow(1), func(metar *METAR) error {
+		metars = append(metars, metar)
+		return errStop
+	})
+	if !errors.Is(err, errStop) || len(metars) != 1 {
+		t.Errorf("expected the callback error to stop the stream after 1 METAR, got %v after %d", err, len(metars))
+	}
+	if sky := metars[0].SkyConditions; len(sky) != 1 || sky[0] != (METARSkyCondition{"FEW", 2000}) {
+		t.Errorf("unexpected sky conditions: %+v", sky)
+	}
+}
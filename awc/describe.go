@@ -0,0 +1,118 @@
+package awc
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var skyCoverDescriptions = map[SkyCover]string{
+	SkyCoverSKC:   "sky clear",
+	SkyCoverCLR:   "sky clear",
+	SkyCoverCAVOK: "ceiling and visibility OK",
+	SkyCoverNSC:   "no significant clouds",
+	SkyCoverFEW:   "few clouds",
+	SkyCoverSCT:   "scattered clouds",
+	SkyCoverBKN:   "broken clouds",
+	SkyCoverOVC:   "overcast",
+}
+
+var cloudTypeDescriptions = map[string]string{
+	"CB":  "cumulonimbus",
+	"TCU": "towering cumulus",
+}
+
+func formatNumber(value float32) string {
+	return strconv.FormatFloat(float64(value), 'f', -1, 32)
+}
+
+func describeTemperature(value float32) string {
+	rounded := float32(math.Round(float64(value)))
+	if rounded < 0 {
+		return "minus " + formatNumber(-rounded)
+	}
+	return formatNumber(rounded)
+}
+
+// describeWind renders the wind in plain English, e.g. "winds from the west at 12 knots gusting 20"
+func (metar *METAR) describeWind() string {
+	switch {
+	case metar.IsWindMissing():
+		return ""
+	case metar.IsCalm():
+		return "winds calm"
+	}
+
+	description := fmt.Sprintf("winds from the %s at %d knots", compassPointOf(metar.WindDirDegrees).name,
+		metar.WindSpeedKT)
+	if metar.IsVariableWind() {
+		description = fmt.Sprintf("winds variable at %d knots", metar.WindSpeedKT)
+	}
+	if metar.WindGustKT > 0 {
+		description += fmt.Sprintf(" gusting %d", metar.WindGustKT)
+	}
+	return description
+}
+
+// describeClouds renders the classified cloud layers in plain English, e.g. "broken clouds at 2500 feet"
+func (metar *METAR) describeClouds() []string {
+	var descriptions []string
+	for _, layer := range metar.CloudLayers() {
+		var description string
+		switch layer.Cover {
+		case SkyCoverUnknown:
+			continue
+		case SkyCoverOVX:
+			description = fmt.Sprintf("sky obscured, vertical visibility %d feet", layer.BaseFT)
+		case SkyCoverFEW, SkyCoverSCT, SkyCoverBKN, SkyCoverOVC:
+			description = fmt.Sprintf("%s at %d feet", skyCoverDescriptions[layer.Cover], layer.BaseFT)
+		default:
+			description = skyCoverDescriptions[layer.Cover]
+		}
+		if cloudType, ok := cloudTypeDescriptions[layer.CloudType]; ok {
+			description += fmt.Sprintf(" (%s)", cloudType)
+		}
+		descriptions = append(descriptions, description)
+	}
+	return descriptions
+}
+
+// Describe renders the METAR in plain English, e.g. "At KORD, winds from the west at 12 knots gusting 20, visibility 10
+// miles, broken clouds at 2500 feet, temperature minus 5, dewpoint minus 12, altimeter 30.02, VFR."
+// The description is composed from the decoded wind, visibility, weather, cloud, temperature, altimeter and flight
+// category values; missing values are skipped.
+func (metar *METAR) Describe() string {
+	var parts []string
+
+	if wind := metar.describeWind(); wind != "" {
+		parts = append(parts, wind)
+	}
+	if metar.hasVisibility() {
+		parts = append(parts, fmt.Sprintf("visibility %s miles", formatNumber(metar.VisibilityStatuteMI)))
+	}
+	for _, phenomenon := range metar.Weather() {
+		parts = append(parts, phenomenon.Description())
+	}
+	parts = append(parts, metar.describeClouds()...)
+
+	temperature, dewPoint := metar.hasTemperatures()
+	if temperature {
+		parts = append(parts, "temperature "+describeTemperature(metar.AirTempC))
+	}
+	if dewPoint {
+		parts = append(parts, "dewpoint "+describeTemperature(metar.DewPointC))
+	}
+	if metar.AltimeterInHG > 0 {
+		parts = append(parts, fmt.Sprintf("altimeter %.2f", metar.AltimeterInHG))
+	}
+
+	if category := metar.flightCategory(); category != "" {
+		parts = append(parts, category)
+	}
+
+	if len(parts) == 0 {
+		return fmt.Sprintf("At %s, no data reported.", metar.StationID)
+	}
+	return fmt.Sprintf("At %s, %s.", metar.StationID, strings.Join(parts, ", "))
+}
@@ -1,29 +1,124 @@
 package awc
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+)
+
+// endpoint represents the query string of a request to the data server and the format of the requested data
+type endpoint struct {
+	query  string
+	format Format
+}
 
-type endpoint string
+const defaultBaseURL = "https://aviationweather.gov/adds/dataserver_current/httpparam"
+
+// DataSource represents a data source of the AWC Text Data Server
+type DataSource string
 
 const (
-	endpointMETAR endpoint = "https://aviationweather.gov/adds/dataserver_current/httpparam?dataSource=metars&requestType=retrieve&format=xml"
+	DataSourceMETARs          DataSource = "metars"
+	DataSourceTAFs            DataSource = "tafs"
+	DataSourceAircraftReports DataSource = "aircraftreports"
+	DataSourceAirSigmets      DataSource = "airsigmets"
+	DataSourceStations        DataSource = "stations"
+)
+
+// Format represents a wire format of the AWC Text Data Server
+type Format string
+
+const (
+	// FormatXML is the default format
+	FormatXML Format = "xml"
+	// FormatJSON is only supported by some deployments of the data server
+	FormatJSON Format = "json"
+
+	// formatCSV is requested by the dedicated CSV methods only
+	formatCSV Format = "csv"
+)
+
+var (
+	endpointMETAR    = DataSourceMETARs.endpoint(FormatXML)
+	endpointMETARCSV = DataSourceMETARs.endpoint(formatCSV)
+	endpointStations = DataSourceStations.endpoint(FormatXML)
 )
 
+// endpoint creates the endpoint retrieving data of the data source in the given format
+func (source DataSource) endpoint(format Format) endpoint {
+	return endpoint{
+		query:  fmt.Sprintf("dataSource=%s&requestType=retrieve&format=%s", source, format),
+		format: format,
+	}
+}
+
+// formatMediaTypes maps the formats of the data server to the media types accepted for them.
+// The first media type is preferred when negotiating the content type.
+var formatMediaTypes = map[Format][]string{
+	FormatXML:  {"text/xml", "application/xml"},
+	FormatJSON: {"application/json"},
+	formatCSV:  {"text/csv", "application/csv", "text/plain"},
+}
+
+// accept returns the value of the Accept header matching the format of the endpoint
+func (end endpoint) accept() string {
+	mediaTypes := formatMediaTypes[end.format]
+	if len(mediaTypes) == 0 {
+		return "*/*"
+	}
+	return strings.Join(mediaTypes, ", ")
+}
+
+// checkContentType returns an error if the given value of a Content-Type header does not match the format of the
+// endpoint.
+// Missing content types are accepted.
+func (end endpoint) checkContentType(contentType string) error {
+	mediaTypes, ok := formatMediaTypes[end.format]
+	if contentType == "" || !ok {
+		return nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return errors.New(fmt.Sprintf("invalid content type: %q", contentType))
+	}
+	if end.format == FormatXML && strings.HasSuffix(mediaType, "+xml") {
+		return nil
+	}
+	for _, accepted := range mediaTypes {
+		if mediaType == accepted {
+			return nil
+		}
+	}
+	expected := strings.Join(mediaTypes, " or ")
+	return errors.New(fmt.Sprintf("unexpected content type: %q (expected %s)", contentType, expected))
+}
+
 func (end endpoint) addString(key, value string) endpoint {
-	return endpoint(fmt.Sprintf("%s&%s=%s", end, key, value))
+	end.query = fmt.Sprintf("%s&%s=%s", end.query, key, value)
+	return end
 }
 
 func (end endpoint) addBool(key string, value bool) endpoint {
-	return endpoint(fmt.Sprintf("%s&%s=%t", end, key, value))
+	end.query = fmt.Sprintf("%s&%s=%t", end.query, key, value)
+	return end
 }
 
 func (end endpoint) addInt(key string, value int64) endpoint {
-	return endpoint(fmt.Sprintf("%s&%s=%d", end, key, value))
+	end.query = fmt.Sprintf("%s&%s=%d", end.query, key, value)
+	return end
 }
 
 func (end endpoint) addFloat(key string, value float32) endpoint {
-	return endpoint(fmt.Sprintf("%s&%s=%f", end, key, value))
+	end.query = fmt.Sprintf("%s&%s=%f", end.query, key, value)
+	return end
+}
+
+func (end endpoint) withBase(baseURL string) string {
+	return fmt.Sprintf("%s?%s", baseURL, end.query)
 }
 
 func (end endpoint) String() string {
-	return string(end)
+	return end.query
 }
@@ -0,0 +1,45 @@
+package awc
+
+import "testing"
+
+func TestEndpointFormat(t *testing.T) {
+	tests := []struct {
+		end  endpoint
+		want Format
+	}{
+		{endpointMETAR, FormatXML},
+		{endpointMETARCSV, formatCSV},
+		{DataSourceMETARs.endpoint(FormatJSON), FormatJSON},
+		{endpointMETAR.addString("stationString", "format=json"), FormatXML},
+		{endpointMETAR.addString("fields", "raw_text").addFloat("hoursBeforeNow", 1), FormatXML},
+	}
+	for _, test := range tests {
+		if test.end.format != test.want {
+			t.Errorf("format of %s = %q; want %q", test.end, test.end.format, test.want)
+		}
+	}
+
+	query := NewMETARQuery().Station("format=csv").HoursBeforeNow(1).Format(FormatJSON)
+	if end := query.buildEndpoint(); end.format != FormatJSON || end.accept() != "application/json" {
+		t.Errorf("got format %q accepting %q; want %q", end.format, end.accept(), FormatJSON)
+	}
+}
+
+func TestDataSourceEndpoint(t *testing.T) {
+	tests := []struct {
+		end  endpoint
+		want string
+	}{
+		{endpointMETAR, "dataSource=metars&requestType=retrieve&format=xml"},
+		{endpointMETARCSV, "dataSource=metars&requestType=retrieve&format=csv"},
+		{endpointStations, "dataSource=stations&requestType=retrieve&format=xml"},
+		{DataSourceTAFs.endpoint(FormatXML), "dataSource=tafs&requestType=retrieve&format=xml"},
+		{DataSourceAircraftReports.endpoint(FormatXML), "dataSource=aircraftreports&requestType=retrieve&format=xml"},
+		{DataSourceAirSigmets.endpoint(FormatXML), "dataSource=airsigmets&requestType=retrieve&format=xml"},
+	}
+	for _, test := range tests {
+		if got := test.end.String(); got != test.want {
+			t.Errorf("endpoint = %q; want %q", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package awc
+
+// FogRiskLevel represents the risk of fog formation assessed by FogRisk
+type FogRiskLevel int
+
+const (
+	// FogRiskUnknown is returned if the METAR lacks the temperature or dew point
+	FogRiskUnknown FogRiskLevel = iota
+	FogRiskLow
+	FogRiskModerate
+	FogRiskHigh
+)
+
+func (level FogRiskLevel) String() string {
+	switch level {
+	case FogRiskLow:
+		return "low"
+	case FogRiskModerate:
+		return "moderate"
+	case FogRiskHigh:
+		return "high"
+	default:
+		return "unknown"
+	}
+}
+
+// FogThresholds specifies the criteria FogRiskWith uses to assess the risk of fog formation
+type FogThresholds struct {
+	// MaxSpreadC is the maximum temperature/dew point spread in degrees Celsius
+	MaxSpreadC float32
+	// MaxWindKT is the maximum sustained wind speed in knots; calm winds always satisfy this criterion
+	MaxWindKT int
+	// MinCeilingFT is the minimum ceiling in feet; skies without a ceiling always satisfy this criterion, as clear
+	// skies allow the radiative cooling fog forms from
+	MinCeilingFT int
+}
+
+// DefaultFogThresholds returns the thresholds used by FogRisk, i.e. a spread of at most 2 °C, a wind of at most 5
+// knots and a ceiling of at least 10000 feet
+func DefaultFogThresholds() FogThresholds {
+	return FogThresholds{
+		MaxSpreadC:   2,
+		MaxWindKT:    5,
+		MinCeilingFT: 10000,
+	}
+}
+
+// DewpointSpreadC returns the difference between the air temperature and the dew point in degrees Celsius.
+// ok is false if the METAR lacks either value.
+func (metar *METAR) DewpointSpreadC() (float32, bool) {
+	temperature, dewPoint := metar.hasTemperatures()
+	if !temperature || !dewPoint {
+		return 0, false
+	}
+	return metar.AirTempC - metar.DewPointC, true
+}
+
+// FogRisk assesses the risk of fog formation using DefaultFogThresholds.
+// Please refer to FogRiskWith for further information.
+func (metar *METAR) FogRisk() FogRiskLevel {
+	return metar.FogRiskWith(DefaultFogThresholds())
+}
+
+// FogRiskWith assesses the risk of fog formation based on three criteria: a small temperature/dew point spread, light
+// or calm winds and clear or high skies (see FogThresholds).
+// A small spread is a prerequisite: without it the risk is low. Otherwise, it is high if both other criteria are met
+// and moderate if only one of them is; missing wind or sky data does not satisfy the respective criterion.
+// FogRiskUnknown is returned if the spread can not be determined. Please keep in mind that this is a simple indicator
+// that does not consider trends, terrain or the time of day.
+func (metar *METAR) FogRiskWith(thresholds FogThresholds) FogRiskLevel {
+	spread, ok := metar.DewpointSpreadC()
+	if !ok {
+		return FogRiskUnknown
+	}
+	if spread > thresholds.MaxSpreadC {
+		return FogRiskLow
+	}
+
+	met := 0
+	if metar.IsCalm() || (!metar.IsWindMissing() && metar.WindSpeedKT <= thresholds.MaxWindKT) {
+		met++
+	}
+	if len(metar.SkyConditions) > 0 {
+		if ceiling, hasCeiling := metar.Ceiling(); !hasCeiling || ceiling >= thresholds.MinCeilingFT {
+			met++
+		}
+	}
+
+	switch met {
+	case 2:
+		return FogRiskHigh
+	case 1:
+		return FogRiskModerate
+	default:
+		return FogRiskLow
+	}
+}
@@ -0,0 +1,45 @@
+package awc
+
+import "testing"
+
+func TestFogRisk(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want FogRiskLevel
+	}{
+		{"KSFO 011256Z 00000KT 10SM SKC 15/13 A3002", FogRiskHigh},
+		{"KSFO 011256Z 00000KT 10SM SKC 15/12 A3002", FogRiskLow},
+		{"KSFO 011256Z 28005KT 10SM FEW020 M01/M03 A3002", FogRiskHigh},
+		{"KSFO 011256Z 28006KT 10SM FEW020 15/13 A3002", FogRiskModerate},
+		{"KSFO 011256Z 28005KT 10SM OVC100 15/13 A3002", FogRiskHigh},
+		{"KSFO 011256Z 28005KT 10SM BKN095 15/13 A3002", FogRiskModerate},
+		{"KSFO 011256Z 28006KT 10SM BKN095 15/13 A3002", FogRiskLow},
+		{"KSFO 011256Z 28010KT 10SM BKN095 15/15 A3002", FogRiskLow},
+		{"KSFO 011256Z 10SM SKC 15/14 A3002", FogRiskModerate},
+		{"KSFO 011256Z 00000KT 10SM SKC A3002", FogRiskUnknown},
+	}
+	for _, test := range tests {
+		if got := mustParseMETAR(t, test.raw).FogRisk(); got != test.want {
+			t.Errorf("FogRisk(%q) = %s; want %s", test.raw, got, test.want)
+		}
+	}
+}
+
+func TestFogRiskWith(t *testing.T) {
+	metar := mustParseMETAR(t, "KSFO 011256Z 28008KT 10SM BKN030 15/12 A3002")
+	tests := []struct {
+		thresholds FogThresholds
+		want       FogRiskLevel
+	}{
+		{DefaultFogThresholds(), FogRiskLow},
+		{FogThresholds{MaxSpreadC: 3, MaxWindKT: 8, MinCeilingFT: 3000}, FogRiskHigh},
+		{FogThresholds{MaxSpreadC: 3, MaxWindKT: 7, MinCeilingFT: 3000}, FogRiskModerate},
+		{FogThresholds{MaxSpreadC: 3, MaxWindKT: 7, MinCeilingFT: 3100}, FogRiskLow},
+		{FogThresholds{MaxSpreadC: 2.9, MaxWindKT: 8, MinCeilingFT: 3000}, FogRiskLow},
+	}
+	for _, test := range tests {
+		if got := metar.FogRiskWith(test.thresholds); got != test.want {
+			t.Errorf("FogRiskWith(%+v) = %s; want %s", test.thresholds, got, test.want)
+		}
+	}
+}
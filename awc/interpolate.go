@@ -0,0 +1,88 @@
+package awc
+
+import "math"
+
+// InterpolateMETAR linearly interpolates between the consecutive observations a and b at the fraction t, where 0
+// results in the values of a and 1 in the ones of b; t is clamped to [0, 1].
+// The following fields are interpolated if both observations report them, otherwise they are carried from the nearer
+// observation:
+//   - AirTempC and DewPointC
+//   - WindSpeedKT and WindGustKT, rounded to whole knots
+//   - WindDirDegrees along the shorter arc, rounded to whole degrees; calm and variable winds are carried
+//   - AltimeterInHG and SeaLevelPressureMB
+//   - VisibilityStatuteMI
+//
+// All other fields, including the raw text, the observation time, the weather, the sky conditions and the flight
+// category, are carried from the nearer observation, i.e. a if t is less than 0.5 and b otherwise. Please keep in mind
+// that the helper methods working on the raw text thus describe the nearer observation. Converted is recomputed from
+// the interpolated values in the unit system of the nearer observation, and the result does not share any memory with
+// a or b.
+// nil is returned if a or b is nil.
+func InterpolateMETAR(a, b *METAR, t float32) *METAR {
+	if a == nil || b == nil {
+		return nil
+	}
+	t = keepFloatInRange(t, 0, 1)
+
+	interpolated := *b
+	if t < 0.5 {
+		interpolated = *a
+	}
+	interpolated.SkyConditions = append([]METARSkyCondition(nil), interpolated.SkyConditions...)
+	if interpolated.Station != nil {
+		station := *interpolated.Station
+		interpolated.Station = &station
+	}
+
+	lerp := func(from, to float32) float32 {
+		return from + (to-from)*t
+	}
+	lerpInt := func(from, to int) int {
+		return int(math.Round(float64(lerp(float32(from), float32(to)))))
+	}
+
+	aTemperature, aDewPoint := a.hasTemperatures()
+	bTemperature, bDewPoint := b.hasTemperatures()
+	if aTemperature && bTemperature {
+		interpolated.AirTempC = lerp(a.AirTempC, b.AirTempC)
+	}
+	if aDewPoint && bDewPoint {
+		interpolated.DewPointC = lerp(a.DewPointC, b.DewPointC)
+	}
+
+	if !a.IsWindMissing() && !b.IsWindMissing() {
+		interpolated.WindSpeedKT = lerpInt(a.WindSpeedKT, b.WindSpeedKT)
+		if a.WindGustKT > 0 && b.WindGustKT > 0 {
+			interpolated.WindGustKT = lerpInt(a.WindGustKT, b.WindGustKT)
+		}
+		if a.hasWindDirection() && b.hasWindDirection() {
+			arc := (b.WindDirDegrees-a.WindDirDegrees+540)%360 - 180
+			direction := (a.WindDirDegrees + lerpInt(0, arc) + 360) % 360
+			if direction == 0 {
+				direction = 360
+			}
+			interpolated.WindDirDegrees = direction
+		}
+	}
+
+	if a.AltimeterInHG > 0 && b.AltimeterInHG > 0 {
+		interpolated.AltimeterInHG = lerp(a.AltimeterInHG, b.AltimeterInHG)
+	}
+	if a.SeaLevelPressureMB > 0 && b.SeaLevelPressureMB > 0 {
+		interpolated.SeaLevelPressureMB = lerp(a.SeaLevelPressureMB, b.SeaLevelPressureMB)
+	}
+	if a.hasVisibility() && b.hasVisibility() {
+		interpolated.VisibilityStatuteMI = lerp(a.VisibilityStatuteMI, b.VisibilityStatuteMI)
+	}
+
+	if interpolated.Converted != nil {
+		interpolated.Converted = interpolated.convert(interpolated.Converted.Units)
+	}
+	return &interpolated
+}
+
+// hasWindDirection reports whether the METAR reports a wind direction, i.e. the wind is neither missing, calm nor
+// variable
+func (metar *METAR) hasWindDirection() bool {
+	return !metar.IsWindMissing() && !metar.IsCalm() && !metar.IsVariableWind() && metar.WindDirDegrees > 0
+}
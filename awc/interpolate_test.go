@@ -0,0 +1,47 @@
+package awc
+
+import "testing"
+
+func TestInterpolateMETAR(t *testing.T) {
+	a := &METAR{
+		StationID: "KSFO", AirTempC: 10, DewPointC: 4, WindDirDegrees: 350, WindSpeedKT: 10,
+		AltimeterInHG: 30.00, VisibilityStatuteMI: 10, SkyConditions: []METARSkyCondition{{"FEW", 2000}},
+		Station: &Station{StationID: "KSFO", Site: "San Francisco"},
+	}
+	a.Converted = a.convert(UnitSystemMetric)
+	b := &METAR{
+		StationID: "KSFO", AirTempC: 14, DewPointC: 6, WindDirDegrees: 30, WindSpeedKT: 20,
+		AltimeterInHG: 29.90, VisibilityStatuteMI: 6, SkyConditions: []METARSkyCondition{{"BKN", 1500}},
+		Station: &Station{StationID: "KSFO", Site: "San Francisco"},
+	}
+	b.Converted = b.convert(UnitSystemMetric)
+
+	interpolated := InterpolateMETAR(a, b, 0.25)
+	if interpolated.AirTempC != 11 || interpolated.DewPointC != 4.5 || interpolated.WindSpeedKT != 13 ||
+		interpolated.WindDirDegrees != 360 || interpolated.VisibilityStatuteMI != 9 {
+		t.Errorf("unexpected interpolated values: %+v", interpolated)
+	}
+	if interpolated.SkyConditions[0].SkyCover != "FEW" {
+		t.Errorf("expected the sky conditions of the nearer observation, got %+v", interpolated.SkyConditions)
+	}
+
+	want := interpolated.convert(UnitSystemMetric)
+	if interpolated.Converted == a.Converted || *interpolated.Converted != *want {
+		t.Errorf("got conversions %+v; want %+v", interpolated.Converted, want)
+	}
+	if interpolated.Station == a.Station || *interpolated.Station != *a.Station {
+		t.Errorf("expected an own copy of the station, got %p of %p", interpolated.Station, a.Station)
+	}
+	interpolated.SkyConditions[0].SkyCover = "OVC"
+	interpolated.Station.Site = "modified"
+	if a.SkyConditions[0].SkyCover != "FEW" || a.Station.Site != "San Francisco" {
+		t.Error("modifying the interpolated METAR affected the source observation")
+	}
+
+	if converted := InterpolateMETAR(&METAR{AirTempC: 10}, &METAR{AirTempC: 20}, 0.5); converted.Converted != nil {
+		t.Errorf("expected no conversions, got %+v", converted.Converted)
+	}
+	if InterpolateMETAR(a, nil, 0.5) != nil {
+		t.Error("expected nil for a nil observation")
+	}
+}
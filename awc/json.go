@@ -0,0 +1,124 @@
+package awc
+
+import "encoding/json"
+
+type jsonMETAR struct {
+	Station        string           `json:"station"`
+	Observed       string           `json:"observed,omitempty"`
+	Raw            string           `json:"raw,omitempty"`
+	FlightCategory string           `json:"flight_category,omitempty"`
+	Wind           *jsonWind        `json:"wind"`
+	Visibility     *jsonVisibility  `json:"visibility"`
+	Temperatures   jsonTemperatures `json:"temperatures"`
+	Altimeter      *jsonAltimeter   `json:"altimeter"`
+	Clouds         []jsonCloud      `json:"clouds"`
+	Weather        []jsonWeather    `json:"weather"`
+}
+
+type jsonWind struct {
+	DirectionDegrees *int `json:"direction_degrees"`
+	SpeedKT          int  `json:"speed_kt"`
+	GustKT           *int `json:"gust_kt"`
+	Variable         bool `json:"variable"`
+	Calm             bool `json:"calm"`
+}
+
+type jsonVisibility struct {
+	StatuteMI float32 `json:"statute_mi"`
+}
+
+type jsonTemperatures struct {
+	AirC      *float32 `json:"air_c"`
+	DewPointC *float32 `json:"dewpoint_c"`
+}
+
+type jsonAltimeter struct {
+	InHG float32 `json:"in_hg"`
+}
+
+type jsonCloud struct {
+	Cover     string `json:"cover"`
+	BaseFTAGL int    `json:"base_ft_agl"`
+	Ceiling   bool   `json:"ceiling"`
+	Type      string `json:"type,omitempty"`
+}
+
+type jsonWeather struct {
+	Raw         string   `json:"raw"`
+	Intensity   string   `json:"intensity,omitempty"`
+	Vicinity    bool     `json:"vicinity"`
+	Descriptor  string   `json:"descriptor,omitempty"`
+	Phenomena   []string `json:"phenomena"`
+	Description string   `json:"description"`
+}
+
+// ToJSON renders the METAR as a normalized, nested JSON object.
+// In contrast to marshaling the METAR directly, the values are decoded and grouped: the object consists of the
+// 'station', 'observed', 'raw' and 'flight_category' strings, the 'wind', 'visibility' and 'altimeter' objects (null if
+// missing), the 'temperatures' object with nullable 'air_c' and 'dewpoint_c' values and the 'clouds' and 'weather'
+// arrays.
+func (metar *METAR) ToJSON() ([]byte, error) {
+	object := jsonMETAR{
+		Station:        metar.StationID,
+		Observed:       metar.ObservationTime,
+		Raw:            metar.RawText,
+		FlightCategory: metar.FlightCategory,
+		Clouds:         []jsonCloud{},
+		Weather:        []jsonWeather{},
+	}
+
+	if !metar.IsWindMissing() {
+		wind := &jsonWind{
+			SpeedKT:  metar.WindSpeedKT,
+			Variable: metar.IsVariableWind(),
+			Calm:     metar.IsCalm(),
+		}
+		if !wind.Variable && !wind.Calm {
+			direction := metar.WindDirDegrees
+			wind.DirectionDegrees = &direction
+		}
+		if metar.WindGustKT > 0 {
+			gust := metar.WindGustKT
+			wind.GustKT = &gust
+		}
+		object.Wind = wind
+	}
+
+	if metar.VisibilityStatuteMI > 0 {
+		object.Visibility = &jsonVisibility{StatuteMI: metar.VisibilityStatuteMI}
+	}
+
+	temperature, dewPoint := metar.hasTemperatures()
+	if temperature {
+		object.Temperatures.AirC = &metar.AirTempC
+	}
+	if dewPoint {
+		object.Temperatures.DewPointC = &metar.DewPointC
+	}
+
+	if metar.AltimeterInHG > 0 {
+		object.Altimeter = &jsonAltimeter{InHG: metar.AltimeterInHG}
+	}
+
+	for _, layer := range metar.CloudLayers() {
+		object.Clouds = append(object.Clouds, jsonCloud{
+			Cover:     layer.Cover.String(),
+			BaseFTAGL: layer.BaseFT,
+			Ceiling:   layer.IsCeiling,
+			Type:      layer.CloudType,
+		})
+	}
+
+	for _, phenomenon := range metar.Weather() {
+		object.Weather = append(object.Weather, jsonWeather{
+			Raw:         phenomenon.Raw,
+			Intensity:   phenomenon.Intensity,
+			Vicinity:    phenomenon.Vicinity,
+			Descriptor:  phenomenon.Descriptor,
+			Phenomena:   append([]string{}, phenomenon.Phenomena...),
+			Description: phenomenon.Description(),
+		})
+	}
+
+	return json.Marshal(object)
+}
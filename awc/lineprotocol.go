@@ -0,0 +1,80 @@
+package awc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// lineProtocolFields contains the METAR fields written by WriteLineProtocol; integer fields get the 'i' suffix
+var lineProtocolFields = []struct {
+	name    string
+	integer bool
+}{
+	{"temp_c", false},
+	{"dewpoint_c", false},
+	{"wind_dir_degrees", true},
+	{"wind_speed_kt", true},
+	{"wind_gust_kt", true},
+	{"visibility_statute_mi", false},
+	{"altim_in_hg", false},
+	{"sea_level_pressure_mb", false},
+	{"vert_vis_ft", true},
+	{"precip_in", false},
+	{"snow_in", false},
+}
+
+var (
+	lineProtocolMeasurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	lineProtocolTagEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+)
+
+// WriteLineProtocol writes the METARs of the response to w using the InfluxDB line protocol, one line per METAR.
+// Every line carries the station ID as the station tag and, if known, the flight category as the flight_category tag.
+// The fields are named like the respective fields of the XML format: temp_c, dewpoint_c, wind_dir_degrees,
+// wind_speed_kt, wind_gust_kt, visibility_statute_mi, altim_in_hg, sea_level_pressure_mb, vert_vis_ft, precip_in and
+// snow_in. Missing fields are omitted following the rules of WriteCSV; METARs without any field are skipped. The
+// timestamp is the observation time in nanoseconds.
+// An error is returned if the observation time of any METAR can not be parsed.
+func (response *METARResponse) WriteLineProtocol(w io.Writer, measurement string) error {
+	if measurement == "" {
+		return errors.New("missing measurement")
+	}
+
+	writer := bufio.NewWriter(w)
+	for _, metar := range response.METARs {
+		observedAt, err := metar.ObservedAt()
+		if err != nil {
+			return err
+		}
+
+		var fields []string
+		for _, field := range lineProtocolFields {
+			columns, _ := csvColumnsOf(field.name)
+			value := columns[0].get(metar)
+			if value == "" {
+				continue
+			}
+			if field.integer {
+				value += "i"
+			}
+			fields = append(fields, field.name+"="+value)
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		line := lineProtocolMeasurementEscaper.Replace(measurement) +
+			",station=" + lineProtocolTagEscaper.Replace(metar.StationID)
+		if metar.FlightCategory != "" {
+			line += ",flight_category=" + lineProtocolTagEscaper.Replace(metar.FlightCategory)
+		}
+		line += " " + strings.Join(fields, ",")
+		if _, err := fmt.Fprintf(writer, "%s %d\n", line, observedAt.UnixNano()); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
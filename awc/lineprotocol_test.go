@@ -0,0 +1,48 @@
+package awc
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteLineProtocol(t *testing.T) {
+	response := &METARResponse{METARs: []*METAR{
+		{
+			RawText:         "KSFO 011256Z 28012G20KT 10SM FEW020 15/08 A3002 RMK AO2 SLP165",
+			StationID:       "KSFO",
+			ObservationTime: "2024-05-01T12:56:00Z",
+			AirTempC:        15, DewPointC: 8,
+			WindDirDegrees: 280, WindSpeedKT: 12, WindGustKT: 20,
+			VisibilityStatuteMI: 10, AltimeterInHG: 30.02, SeaLevelPressureMB: 1016.5,
+			FlightCategory: FlightCategoryVFR,
+		},
+		{
+			RawText:         "X Y,Z=1 011253Z 00000KT 1/4SM FG VV002 00/M01 A2992",
+			StationID:       "X Y,Z=1",
+			ObservationTime: "2024-05-01T12:53:00.123456789Z",
+			DewPointC:       -1, VisibilityStatuteMI: 0.25, AltimeterInHG: 29.92, VerticalVisibilityFT: 200,
+		},
+		{RawText: "KOAK 011253Z RMK AO2", StationID: "KOAK", ObservationTime: "2024-05-01T12:53:00Z"},
+	}}
+
+	var buffer bytes.Buffer
+	if err := response.WriteLineProtocol(&buffer, "metar obs,v2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `metar\ obs\,v2,station=KSFO,flight_category=VFR temp_c=15,dewpoint_c=8,wind_dir_degrees=280i,` +
+		`wind_speed_kt=12i,wind_gust_kt=20i,visibility_statute_mi=10,altim_in_hg=30.02,sea_level_pressure_mb=1016.5 ` +
+		"1714568160000000000\n" +
+		`metar\ obs\,v2,station=X\ Y\,Z\=1 temp_c=0,dewpoint_c=-1,wind_dir_degrees=0i,wind_speed_kt=0i,` +
+		`visibility_statute_mi=0.25,altim_in_hg=29.92,vert_vis_ft=200i 1714567980123456789` + "\n"
+	if buffer.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", buffer.String(), want)
+	}
+
+	if err := response.WriteLineProtocol(&buffer, ""); err == nil {
+		t.Error("expected an error for a missing measurement")
+	}
+	invalid := &METARResponse{METARs: []*METAR{{StationID: "KSFO", AirTempC: 15}}}
+	if err := invalid.WriteLineProtocol(&buffer, "metar"); err == nil {
+		t.Error("expected an error for a missing observation time")
+	}
+}
@@ -1,5 +1,7 @@
 package awc
 
+import "math"
+
 func keepFloatInRange(value, min, max float32) float32 {
 	if value <= min {
 		return min
@@ -9,3 +11,23 @@ func keepFloatInRange(value, min, max float32) float32 {
 	}
 	return value
 }
+
+// earthRadiusMI is the mean radius of the earth in statute miles
+const earthRadiusMI = 3958.8
+
+// smPerNM is the amount of statute miles in a nautical mile
+const smPerNM = 1.150779
+
+// haversine calculates the great-circle distance between two coordinates in statute miles
+func haversine(lat1, lon1, lat2, lon2 float32) float32 {
+	toRadians := func(degrees float32) float64 {
+		return float64(degrees) * math.Pi / 180
+	}
+
+	deltaLat := toRadians(lat2 - lat1)
+	deltaLon := toRadians(lon2 - lon1)
+
+	a := math.Pow(math.Sin(deltaLat/2), 2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Pow(math.Sin(deltaLon/2), 2)
+	return float32(2 * earthRadiusMI * math.Asin(math.Sqrt(a)))
+}
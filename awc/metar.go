@@ -1,21 +1,32 @@
 package awc
 
 import (
+	"context"
 	"encoding/xml"
 	"errors"
 	"fmt"
-	"io"
 	"math"
-	"net/http"
 	"strings"
 	"time"
 )
 
+const (
+	// maxRadialDistance is the maximum radius in statute miles the server accepts for radial distance searches
+	maxRadialDistance = 500
+	// maxHoursBeforeNow is the maximum amount of hours the server retains METARs for
+	maxHoursBeforeNow = 72
+	// nearestStationStartRadius is the radius in statute miles a nearest station search starts with
+	nearestStationStartRadius = 25
+)
+
 // METARQuery represents the query used to fetch METAR objects.
-// Please keep in mind that a call either to HoursBeforeNow or Between is required.
+// Please keep in mind that a call either to HoursBeforeNow or Between is required; executing a query without a time
+// constraint fails with an error wrapping ErrMissingTimeConstraint before any request is sent.
 // Please refer to https://aviationweather.gov/dataserver/example?datatype=metar for further information.
 type METARQuery struct {
 	station                                        *string
+	translateIATA                                  bool
+	iataMapping                                    map[string]string
 	startTime, endTime                             *int64
 	hoursBeforeNow                                 *float32
 	mostRecent                                     *bool
@@ -23,6 +34,16 @@ type METARQuery struct {
 	rectMinLat, rectMinLon, rectMaxLat, rectMaxLon *float32
 	radRadius, radLat, radLon                      *float32
 	fields                                         []string
+	adjustments                                    adjustments
+	strict                                         bool
+	coordinatePrecision                            *float32
+	format                                         Format
+}
+
+// NewMETARQuery creates a new empty METARQuery ready for chaining.
+// Using the zero value of METARQuery directly is still valid.
+func NewMETARQuery() *METARQuery {
+	return new(METARQuery)
 }
 
 // Station specifies the station string to use for METAR querying
@@ -31,6 +52,21 @@ func (query *METARQuery) Station(value string) *METARQuery {
 	return query
 }
 
+// Stations specifies multiple stations to use for METAR querying.
+// This overrides any station string specified using Station.
+func (query *METARQuery) Stations(values ...string) *METARQuery {
+	return query.Station(strings.Join(values, ","))
+}
+
+// TranslateIATA specifies whether to translate 3-letter IATA codes of the station string to ICAO codes.
+// The optional mapping is used for airports the 'K' prefix heuristic does not apply to.
+// Please refer to ICAOFromIATA for the limitations of the translation.
+func (query *METARQuery) TranslateIATA(value bool, mapping map[string]string) *METARQuery {
+	query.translateIATA = value
+	query.iataMapping = mapping
+	return query
+}
+
 // Between specifies a timespan to fetch the METAR(s) in.
 // If HoursBeforeNow was used before, that will be ignored.
 func (query *METARQuery) Between(start, end time.Time) *METARQuery {
@@ -41,14 +77,20 @@ func (query *METARQuery) Between(start, end time.Time) *METARQuery {
 	query.endTime = &endUnix
 
 	query.hoursBeforeNow = nil
+	query.adjustments.clear("hoursBeforeNow")
 
 	return query
 }
 
 // HoursBeforeNow specifies the amount of hours before the current timestamp to fetch the METAR(s) from.
+// As the server only retains the METARs of the last 3 days, the value is clamped to 72 hours; use Adjustments to check
+// whether that happened.
 // If Between was used before, that will be ignored.
 func (query *METARQuery) HoursBeforeNow(value float32) *METARQuery {
+	query.adjustments.clear("hoursBeforeNow")
+
 	value = float32(math.Abs(float64(value)))
+	value = query.clamp("hoursBeforeNow", value, 0, maxHoursBeforeNow)
 
 	query.hoursBeforeNow = &value
 
@@ -58,6 +100,13 @@ func (query *METARQuery) HoursBeforeNow(value float32) *METARQuery {
 	return query
 }
 
+// Within specifies the maximum age of the METAR(s) to fetch, e.g. Within(75 * time.Minute).
+// This is a shorthand for HoursBeforeNow using the fractional amount of hours of the duration, so the same clamping
+// applies and Between will be ignored if used before.
+func (query *METARQuery) Within(duration time.Duration) *METARQuery {
+	return query.HoursBeforeNow(float32(duration.Hours()))
+}
+
 // MostRecent specifies whether to only include the most recent METAR.
 // If MostRecentForEachStation was used before, that will be ignored.
 func (query *METARQuery) MostRecent(value bool) *METARQuery {
@@ -79,12 +128,15 @@ func (query *METARQuery) MostRecentForEachStation(value string) *METARQuery {
 }
 
 // InRectangle specifies a rectangle consisting of min/max latitude and longitude to fetch the METAR(s) from.
+// Out-of-range values are clamped unless Strict is used; use Adjustments to check whether that happened.
 // If RadialDistance was used before, that will be ignored.
 func (query *METARQuery) InRectangle(minLat, minLon, maxLat, maxLon float32) *METARQuery {
-	minLat = keepFloatInRange(minLat, -90, 90)
-	minLon = keepFloatInRange(minLon, -180, 180)
-	maxLat = keepFloatInRange(maxLat, -90, 90)
-	maxLon = keepFloatInRange(maxLon, -180, 180)
+	query.adjustments.clear(areaParameters...)
+
+	minLat = query.clamp("minLat", minLat, -90, 90)
+	minLon = query.clamp("minLon", minLon, -180, 180)
+	maxLat = query.clamp("maxLat", maxLat, -90, 90)
+	maxLon = query.clamp("maxLon", maxLon, -180, 180)
 
 	query.rectMinLat = &minLat
 	query.rectMinLon = &minLon
@@ -99,14 +151,19 @@ func (query *METARQuery) InRectangle(minLat, minLon, maxLat, maxLon float32) *ME
 }
 
 // RadialDistance specifies a radial distance consisting of latitude, longitude and radius to fetch the METAR(s) from.
+// Out-of-range values are clamped unless Strict is used; use Adjustments to check whether that happened.
 // If InRectangle was used before, that will be ignored.
 func (query *METARQuery) RadialDistance(radius, lat, lon float32) *METARQuery {
-	radius = keepFloatInRange(radius, 0, 500)
-	if radius == 0 {
-		radius = 1
+	query.adjustments.clear(areaParameters...)
+
+	adjustedRadius := keepFloatInRange(radius, 0, maxRadialDistance)
+	if adjustedRadius == 0 {
+		adjustedRadius = 1
 	}
-	lat = keepFloatInRange(lat, -90, 90)
-	lon = keepFloatInRange(lon, -180, 180)
+	query.adjustments.record("radius", radius, adjustedRadius)
+	radius = adjustedRadius
+	lat = query.clamp("lat", lat, -90, 90)
+	lon = query.clamp("lon", lon, -180, 180)
 
 	query.radRadius = &radius
 	query.radLat = &lat
@@ -120,16 +177,130 @@ func (query *METARQuery) RadialDistance(radius, lat, lon float32) *METARQuery {
 	return query
 }
 
-// Fields specifies a list of fields to limit the response to
+// NearestStation specifies a point to search the nearest reporting station for.
+// This is a RadialDistance search starting at a small radius; use it together with GetNearestMETAR, which expands the
+// radius up to the maximum of 500 statute miles until a station is found.
+func (query *METARQuery) NearestStation(lat, lon float32) *METARQuery {
+	return query.RadialDistance(nearestStationStartRadius, lat, lon)
+}
+
+// Adjustments returns the input values that were out of range and thus clamped by HoursBeforeNow, InRectangle or
+// RadialDistance.
+// Only the adjustments of the currently applied constraints are returned.
+func (query *METARQuery) Adjustments() []QueryAdjustment {
+	return append([]QueryAdjustment(nil), query.adjustments...)
+}
+
+// areaParameters contains the names of the parameters of the mutually exclusive InRectangle and RadialDistance
+// constraints
+var areaParameters = []string{"minLat", "minLon", "maxLat", "maxLon", "radius", "lat", "lon"}
+
+func (query *METARQuery) clamp(parameter string, value, min, max float32) float32 {
+	adjusted := keepFloatInRange(value, min, max)
+	query.adjustments.record(parameter, value, adjusted)
+	return adjusted
+}
+
+// CoordinatePrecision specifies a grid size in degrees the coordinates of InRectangle and RadialDistance are snapped to
+// when the request is built, e.g. 0.1 to round them to a tenth of a degree.
+// This trades spatial precision for better cache hit rates, as nearby requests result in the same URL. It is disabled
+// by default; passing a value of 0 or less disables it again.
+func (query *METARQuery) CoordinatePrecision(degrees float32) *METARQuery {
+	if degrees <= 0 {
+		query.coordinatePrecision = nil
+		return query
+	}
+	query.coordinatePrecision = &degrees
+	return query
+}
+
+func (query *METARQuery) snapCoordinate(value float32) float32 {
+	if query.coordinatePrecision == nil {
+		return value
+	}
+	precision := float64(*query.coordinatePrecision)
+	return float32(math.Round(float64(value)/precision) * precision)
+}
+
+// Strict specifies whether out-of-range input values let the query execution fail instead of being clamped silently.
+// This defaults to false; please refer to Adjustments for the values affected by this.
+// Additionally, station IDs not passing IsValidStationID in uppercase let the query execution fail in strict mode;
+// state ('@') and country ('~') selectors are passed through.
+func (query *METARQuery) Strict(value bool) *METARQuery {
+	query.strict = value
+	return query
+}
+
+// Fields specifies a list of fields to limit the response to.
+// Unknown field names will cause the query execution to fail before any request is sent.
 func (query *METARQuery) Fields(values ...string) *METARQuery {
 	query.fields = values
 	return query
 }
 
+// Format specifies the wire format the response is requested in, which defaults to FormatXML.
+// The response is decoded according to the format, so GetMETAR works identically regardless of it. Please keep in mind
+// that not every deployment of the data server supports FormatJSON; the unknown element check of CheckSchema is only
+// applied to XML responses. Other formats let the query execution fail, and the CSV methods always request CSV.
+func (query *METARQuery) Format(value Format) *METARQuery {
+	query.format = value
+	return query
+}
+
+// RawOnly limits the response to the 'raw_text' and 'station_id' fields.
+// This minimizes the response size for frequent polling. Please keep in mind that only the helper methods of METAR
+// working on the raw text (like AltimeterFromRaw, PreciseTemperatures or RunwayVisualRanges) are useful then.
+// It overrides any fields specified using Fields.
+func (query *METARQuery) RawOnly() *METARQuery {
+	return query.Fields("raw_text", "station_id")
+}
+
+// validate returns the first problem letting the query execution fail before any request is sent.
+// In contrast to Validate, a missing or invalid time constraint, unknown fields and, in strict mode, out-of-range
+// values and invalid station IDs are considered only.
+func (query *METARQuery) validate() error {
+	if err := query.checkTimeConstraint(); err != nil {
+		return err
+	}
+	if query.format != "" && query.format != FormatXML && query.format != FormatJSON {
+		return errors.New(fmt.Sprintf("unsupported format: %s", query.format))
+	}
+	if query.strict && len(query.adjustments) > 0 {
+		values := make([]string, 0, len(query.adjustments))
+		for _, adjustment := range query.adjustments {
+			values = append(values, adjustment.String())
+		}
+		return errors.New(fmt.Sprintf("out-of-range value(s): %s", strings.Join(values, "; ")))
+	}
+	if query.strict {
+		if invalid := query.invalidStations(); len(invalid) > 0 {
+			return errors.New(fmt.Sprintf("invalid station ID(s): %s", strings.Join(invalid, ", ")))
+		}
+	}
+	if unknown := query.unknownFields(); len(unknown) > 0 {
+		return errors.New(fmt.Sprintf("unknown METAR field(s): %s", strings.Join(unknown, ", ")))
+	}
+	return nil
+}
+
 func (query *METARQuery) buildEndpoint() endpoint {
-	end := endpointMETAR
+	if query.format != "" && query.format != FormatXML {
+		return query.buildEndpointFrom(DataSourceMETARs.endpoint(query.format))
+	}
+	return query.buildEndpointFrom(endpointMETAR)
+}
+
+func (query *METARQuery) buildEndpointFrom(end endpoint) endpoint {
 	if query.station != nil {
-		end = end.addString("stationString", *query.station)
+		station := *query.station
+		if query.translateIATA {
+			stations := splitStations(station)
+			for i, value := range stations {
+				stations[i] = ICAOFromIATA(value, query.iataMapping)
+			}
+			station = strings.Join(stations, ",")
+		}
+		end = end.addString("stationString", station)
 	}
 	if query.startTime != nil {
 		end = end.addInt("startTime", *query.startTime).addInt("endTime", *query.endTime)
@@ -145,13 +316,14 @@ func (query *METARQuery) buildEndpoint() endpoint {
 	}
 	if query.rectMinLat != nil {
 		end = end.
-			addFloat("minLat", *query.rectMinLat).
-			addFloat("minLon", *query.rectMinLon).
-			addFloat("maxLat", *query.rectMaxLat).
-			addFloat("maxLon", *query.rectMaxLon)
+			addFloat("minLat", query.snapCoordinate(*query.rectMinLat)).
+			addFloat("minLon", query.snapCoordinate(*query.rectMinLon)).
+			addFloat("maxLat", query.snapCoordinate(*query.rectMaxLat)).
+			addFloat("maxLon", query.snapCoordinate(*query.rectMaxLon))
 	}
 	if query.radRadius != nil {
-		end = end.addString("radialDistance", fmt.Sprintf("%f;%f,%f", *query.radRadius, *query.radLon, *query.radLat))
+		end = end.addString("radialDistance", fmt.Sprintf("%f;%f,%f", *query.radRadius,
+			query.snapCoordinate(*query.radLon), query.snapCoordinate(*query.radLat)))
 	}
 	if len(query.fields) > 0 {
 		end = end.addString("fields", strings.Join(query.fields, ","))
@@ -161,88 +333,203 @@ func (query *METARQuery) buildEndpoint() endpoint {
 
 // METARResponse represents the response that gets sent by the AWC Text Data Server
 type METARResponse struct {
-	XMLName  xml.Name `xml:"response"`
-	Errors   []string `xml:"errors>error"`
-	Warnings []string `xml:"warnings>warning"`
-	METARs   []*METAR `xml:"data>METAR"`
+	XMLName  xml.Name `xml:"response" json:"-"`
+	Errors   []string `xml:"errors>error" json:"errors"`
+	Warnings []string `xml:"warnings>warning" json:"warnings"`
+	METARs   []*METAR `xml:"data>METAR" json:"data"`
 }
 
 // METAR represents a single METAR information object
 type METAR struct {
-	RawText                   string                   `xml:"raw_text"`
-	StationID                 string                   `xml:"station_id"`
-	ObservationTime           string                   `xml:"observation_time"`
-	Latitude                  float32                  `xml:"latitude"`
-	Longitude                 float32                  `xml:"longitude"`
-	AirTempC                  float32                  `xml:"temp_c"`
-	DewPointC                 float32                  `xml:"dewpoint_c"`
-	WindDirDegrees            int                      `xml:"wind_dir_degrees"`
-	WindSpeedKT               int                      `xml:"wind_speed_kt"`
-	WindGustKT                int                      `xml:"wind_gust_kt"`
-	VisibilityStatuteMI       float32                  `xml:"visibility_statute_mi"`
-	AltimeterInHG             float32                  `xml:"altim_in_hg"`
-	SeaLevelPressureMB        float32                  `xml:"sea_level_pressure_mb"`
-	QualityControlFlags       METARQualityControlFlags `xml:"quality_control_flags"`
-	WXString                  string                   `xml:"wx_string"`
-	SkyConditions             []METARSkyCondition      `xml:"sky_condition"`
-	FlightCategory            string                   `xml:"flight_category"`
-	ThreeHRPressureTendencyMB float32                  `xml:"three_hr_pressure_tendency_mb"`
-	MaxAirTemp6HC             float32                  `xml:"maxT_c"`
-	MinAirTemp6HC             float32                  `xml:"minT_c"`
-	MaxAirTemp24HC            float32                  `xml:"maxT24hr_c"`
-	MinAirTemp24HC            float32                  `xml:"minT24hr_c"`
-	PrecipitationIN           float32                  `xml:"precip_in"`
-	Precipitation3HIN         float32                  `xml:"pcp3hr_in"`
-	Precipitation6HIN         float32                  `xml:"pcp6hr_in"`
-	Precipitation24HIN        float32                  `xml:"pcp24hr_in"`
-	SnowDepthIN               float32                  `xml:"snow_in"`
-	VerticalVisibilityFT      int                      `xml:"vert_vis_ft"`
-	METARType                 string                   `xml:"metar_type"`
-	ElevationM                float32                  `xml:"elevation_m"`
+	RawText                   string                   `xml:"raw_text" json:"raw_text"`
+	StationID                 string                   `xml:"station_id" json:"station_id"`
+	ObservationTime           string                   `xml:"observation_time" json:"observation_time"`
+	Latitude                  float32                  `xml:"latitude" json:"latitude"`
+	Longitude                 float32                  `xml:"longitude" json:"longitude"`
+	AirTempC                  float32                  `xml:"temp_c" json:"temp_c"`
+	DewPointC                 float32                  `xml:"dewpoint_c" json:"dewpoint_c"`
+	WindDirDegrees            int                      `xml:"wind_dir_degrees" json:"wind_dir_degrees"`
+	WindSpeedKT               int                      `xml:"wind_speed_kt" json:"wind_speed_kt"`
+	WindGustKT                int                      `xml:"wind_gust_kt" json:"wind_gust_kt"`
+	VisibilityStatuteMI       float32                  `xml:"visibility_statute_mi" json:"visibility_statute_mi"`
+	AltimeterInHG             float32                  `xml:"altim_in_hg" json:"altim_in_hg"`
+	SeaLevelPressureMB        float32                  `xml:"sea_level_pressure_mb" json:"sea_level_pressure_mb"`
+	QualityControlFlags       METARQualityControlFlags `xml:"quality_control_flags" json:"quality_control_flags"`
+	WXString                  string                   `xml:"wx_string" json:"wx_string"`
+	SkyConditions             []METARSkyCondition      `xml:"sky_condition" json:"sky_condition"`
+	FlightCategory            string                   `xml:"flight_category" json:"flight_category"`
+	ThreeHRPressureTendencyMB float32                  `xml:"three_hr_pressure_tendency_mb" json:"three_hr_pressure_tendency_mb"`
+	MaxAirTemp6HC             float32                  `xml:"maxT_c" json:"maxT_c"`
+	MinAirTemp6HC             float32                  `xml:"minT_c" json:"minT_c"`
+	MaxAirTemp24HC            float32                  `xml:"maxT24hr_c" json:"maxT24hr_c"`
+	MinAirTemp24HC            float32                  `xml:"minT24hr_c" json:"minT24hr_c"`
+	PrecipitationIN           float32                  `xml:"precip_in" json:"precip_in"`
+	Precipitation3HIN         float32                  `xml:"pcp3hr_in" json:"pcp3hr_in"`
+	Precipitation6HIN         float32                  `xml:"pcp6hr_in" json:"pcp6hr_in"`
+	Precipitation24HIN        float32                  `xml:"pcp24hr_in" json:"pcp24hr_in"`
+	SnowDepthIN               float32                  `xml:"snow_in" json:"snow_in"`
+	VerticalVisibilityFT      int                      `xml:"vert_vis_ft" json:"vert_vis_ft"`
+	METARType                 string                   `xml:"metar_type" json:"metar_type"`
+	ElevationM                float32                  `xml:"elevation_m" json:"elevation_m"`
+
+	// Station contains the metadata of the reporting station if the METAR was fetched using GetMETARWithStations
+	Station *Station `xml:"-" json:"station,omitempty"`
+
+	// Converted contains values converted to the unit system configured on the Client used to fetch the METAR.
+	// It is nil if no unit system was configured; the fields above are never modified.
+	Converted *METARConversions `xml:"-" json:"converted,omitempty"`
+}
+
+// metarFields contains the names of all fields a METAR may consist of.
+// This is used to validate the fields passed to METARQuery.Fields, independent of the requested format.
+var metarFields = []string{
+	"raw_text",
+	"station_id",
+	"observation_time",
+	"latitude",
+	"longitude",
+	"temp_c",
+	"dewpoint_c",
+	"wind_dir_degrees",
+	"wind_speed_kt",
+	"wind_gust_kt",
+	"visibility_statute_mi",
+	"altim_in_hg",
+	"sea_level_pressure_mb",
+	"quality_control_flags",
+	"wx_string",
+	"sky_condition",
+	"flight_category",
+	"three_hr_pressure_tendency_mb",
+	"maxT_c",
+	"minT_c",
+	"maxT24hr_c",
+	"minT24hr_c",
+	"precip_in",
+	"pcp3hr_in",
+	"pcp6hr_in",
+	"pcp24hr_in",
+	"snow_in",
+	"vert_vis_ft",
+	"metar_type",
+	"elevation_m",
+}
+
+func isMETARField(name string) bool {
+	return containsString(metarFields, name)
 }
 
 // METARQualityControlFlags contains the different METAR quality control flags
 type METARQualityControlFlags struct {
-	Corrected               bool `xml:"corrected"`
-	Auto                    bool `xml:"auto"`
-	AutoStation             bool `xml:"auto_station"`
-	MaintenanceIndicator    bool `xml:"maintenance_indicator"`
-	NoSignal                bool `xml:"no_signal"`
-	LightningSensorOff      bool `xml:"lightning_sensor_off"`
-	FreezingRainSensorOff   bool `xml:"freezing_rain_sensor_off"`
-	PresentWeatherSensorOff bool `xml:"present_weather_sensor_off"`
+	Corrected               bool `xml:"corrected" json:"corrected"`
+	Auto                    bool `xml:"auto" json:"auto"`
+	AutoStation             bool `xml:"auto_station" json:"auto_station"`
+	MaintenanceIndicator    bool `xml:"maintenance_indicator" json:"maintenance_indicator"`
+	NoSignal                bool `xml:"no_signal" json:"no_signal"`
+	LightningSensorOff      bool `xml:"lightning_sensor_off" json:"lightning_sensor_off"`
+	FreezingRainSensorOff   bool `xml:"freezing_rain_sensor_off" json:"freezing_rain_sensor_off"`
+	PresentWeatherSensorOff bool `xml:"present_weather_sensor_off" json:"present_weather_sensor_off"`
 }
 
 // METARSkyCondition represents a single METAR sky condition entry
 type METARSkyCondition struct {
-	SkyCover       string `xml:"sky_cover,attr"`
-	CloudBaseFTAGL int    `xml:"cloud_base_ft_agl,attr"`
+	SkyCover       string `xml:"sky_cover,attr" json:"sky_cover"`
+	CloudBaseFTAGL int    `xml:"cloud_base_ft_agl,attr" json:"cloud_base_ft_agl"`
+}
+
+// observationTimeLayouts contains the layouts observation times were seen in, in the order they are tried in.
+// Times without a zone are interpreted as UTC.
+var observationTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04Z07:00",
+}
+
+// ObservedAt parses the observation time of the METAR.
+// Please keep in mind that the format differs slightly between the data servers, so multiple layouts (with and without
+// fractional seconds, 'Z' or numeric zone offsets, 'T' or space as separator) are tried.
+func (metar *METAR) ObservedAt() (time.Time, error) {
+	for _, layout := range observationTimeLayouts {
+		if observedAt, err := time.Parse(layout, metar.ObservationTime); err == nil {
+			return observedAt, nil
+		}
+	}
+	return time.Time{}, errors.New(fmt.Sprintf("unknown observation time format: %q", metar.ObservationTime))
+}
+
+// Age calculates the time elapsed since the observation of the METAR using the current system time.
+// Use Client.Age to calculate the age according to a custom clock.
+func (metar *METAR) Age() (time.Duration, error) {
+	return defaultClient.Age(metar)
 }
 
-// GetMETAR executes a METARQuery.
-// Please keep in mind that this method only returns an error if the request itself failed or the server responded with
-// a non-successful (code < 200 || code > 299) status code.
+// DistanceFrom calculates the great-circle distance between the reporting station and the given coordinates in statute
+// miles
+func (metar *METAR) DistanceFrom(lat, lon float32) float32 {
+	return haversine(metar.Latitude, metar.Longitude, lat, lon)
+}
+
+// GetMETAR executes a METARQuery using the default client.
+// Please keep in mind that this method only returns an error if the query is invalid, the request itself failed or the
+// server responded with a non-successful (code < 200 || code > 299) status code.
 // The returned METARResponse contains separate fields that contain warnings and errors due to the AWC Text Data Server
 // design.
 func GetMETAR(query *METARQuery) (*METARResponse, error) {
-	httpResponse, err := http.Get(query.buildEndpoint().String())
-	if err != nil {
-		return nil, err
-	}
-	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
-		return nil, errors.New(fmt.Sprintf("unexpected status code: %d", httpResponse.StatusCode))
-	}
+	return defaultClient.GetMETAR(query)
+}
 
-	defer httpResponse.Body.Close()
-	body, err := io.ReadAll(httpResponse.Body)
-	if err != nil {
-		return nil, err
-	}
+// GetMETARContext executes a METARQuery using the default client, aborting the request as soon as ctx is done.
+// Please refer to Client.GetMETARContext for further information.
+func GetMETARContext(ctx context.Context, query *METARQuery) (*METARResponse, error) {
+	return defaultClient.GetMETARContext(ctx, query)
+}
 
-	response := new(METARResponse)
-	if err := xml.Unmarshal(body, response); err != nil {
-		return nil, err
-	}
+// GetMETARRaw executes a METARQuery using the default client and additionally returns the unmodified response body.
+// Please refer to Client.GetMETARRaw for further information.
+func GetMETARRaw(query *METARQuery) (*METARResponse, []byte, error) {
+	return defaultClient.GetMETARRaw(query)
+}
+
+// GetMETARs executes a METARQuery and returns only the fetched METARs.
+// In contrast to GetMETAR, this method also returns an error if the AWC Text Data Server reported any errors.
+// Warnings are ignored; use GetMETAR if you need access to them.
+func GetMETARs(query *METARQuery) ([]*METAR, error) {
+	return defaultClient.GetMETARs(query)
+}
+
+// GetNearestMETAR executes a METARQuery built using NearestStation using the default client and returns the METAR of
+// the station closest to the searched point.
+// Please refer to Client.GetNearestMETAR for further information.
+func GetNearestMETAR(query *METARQuery) (*METAR, error) {
+	return defaultClient.GetNearestMETAR(query)
+}
+
+// GetLatestMETAR fetches the most recent METAR of the given station reported within the last 3 hours using the default
+// client.
+// Please refer to Client.GetLatestMETAR for further information.
+func GetLatestMETAR(station string) (*METAR, error) {
+	return defaultClient.GetLatestMETAR(station)
+}
+
+// GetRouteBriefing fetches the latest METARs along a route and determines the limiting station using the default
+// client.
+// Please refer to Client.GetRouteBriefing for further information.
+func GetRouteBriefing(stations ...string) (*RouteBriefing, error) {
+	return defaultClient.GetRouteBriefing(stations...)
+}
+
+// GetMETARPaged executes a METARQuery built using Between in sequential windows using the default client.
+// Please refer to Client.GetMETARPaged for further information.
+func GetMETARPaged(ctx context.Context, query *METARQuery, window time.Duration,
+	callback func(response *METARResponse) error) error {
+	return defaultClient.GetMETARPaged(ctx, query, window, callback)
+}
 
-	return response, nil
+// StreamMETARCSV executes a METARQuery requesting the CSV format using the default client and calls callback with every
+// decoded METAR.
+// Please refer to Client.StreamMETARCSV for further information.
+func StreamMETARCSV(ctx context.Context, query *METARQuery, callback func(metar *METAR) error) error {
+	return defaultClient.StreamMETARCSV(ctx, query, callback)
 }
@@ -0,0 +1,245 @@
+package awc
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestObservedAt(t *testing.T) {
+	want := time.Date(2024, time.May, 1, 12, 56, 0, 0, time.UTC)
+	for _, value := range []string{
+		"2024-05-01T12:56:00Z",
+		"2024-05-01T12:56:00.000Z",
+		"2024-05-01T12:56:00+00:00",
+		"2024-05-01T14:56:00+02:00",
+		"2024-05-01T12:56:00",
+		"2024-05-01T12:56:00.123",
+		"2024-05-01 12:56:00Z",
+		"2024-05-01 12:56:00+00:00",
+		"2024-05-01 12:56:00",
+		"2024-05-01T12:56Z",
+	} {
+		got, err := (&METAR{ObservationTime: value}).ObservedAt()
+		if err != nil {
+			t.Errorf("ObservedAt(%q) failed: %v", value, err)
+			continue
+		}
+		if !got.Truncate(time.Second).Equal(want) {
+			t.Errorf("ObservedAt(%q) = %s; want %s", value, got, want)
+		}
+	}
+
+	for _, value := range []string{"", "01/05/2024 12:56", "2024-05-01", "011256Z"} {
+		if _, err := (&METAR{ObservationTime: value}).ObservedAt(); err == nil {
+			t.Errorf("ObservedAt(%q): expected an error", value)
+		}
+	}
+}
+
+func TestStrictMode(t *testing.T) {
+	var requests []string
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RawQuery)
+		serveMETARs(w, r)
+	})
+
+	tests := []struct {
+		name  string
+		query func() *METARQuery
+		want  string
+	}{
+		{"radius", func() *METARQuery { return NewMETARQuery().RadialDistance(800, 50, 8) },
+			"radialDistance=500.000000;8.000000,50.000000"},
+		{"latitude", func() *METARQuery { return NewMETARQuery().RadialDistance(20, 95, 8) },
+			"radialDistance=20.000000;8.000000,90.000000"},
+		{"rectangle", func() *METARQuery { return NewMETARQuery().InRectangle(-100, -200, 10, 20) },
+			"minLat=-90.000000&minLon=-180.000000&maxLat=10.000000&maxLon=20.000000"},
+		{"hoursBeforeNow", func() *METARQuery { return NewMETARQuery().Station("KSFO").HoursBeforeNow(100) },
+			"hoursBeforeNow=72.000000"},
+	}
+	for _, test := range tests {
+		requests = nil
+		lenient := test.query()
+		if test.name != "hoursBeforeNow" {
+			lenient.HoursBeforeNow(1)
+		}
+		if len(lenient.Adjustments()) == 0 {
+			t.Errorf("%s: expected an adjustment", test.name)
+		}
+		if _, err := client.GetMETAR(lenient); err != nil {
+			t.Errorf("%s: unexpected error in lenient mode: %v", test.name, err)
+		}
+		if len(requests) != 1 || !strings.Contains(requests[0], test.want) {
+			t.Errorf("%s: expected a request containing %q, got %v", test.name, test.want, requests)
+		}
+
+		requests = nil
+		strict := test.query().Strict(true)
+		if test.name != "hoursBeforeNow" {
+			strict.HoursBeforeNow(1)
+		}
+		if _, err := client.GetMETAR(strict); err == nil || !strings.Contains(err.Error(), "out-of-range value(s)") {
+			t.Errorf("%s: expected an out-of-range error in strict mode, got %v", test.name, err)
+		}
+		if len(requests) != 0 {
+			t.Errorf("%s: expected no request in strict mode, got %v", test.name, requests)
+		}
+	}
+}
+
+func TestStrictModeStationIDs(t *testing.T) {
+	query := NewMETARQuery().Stations("KSFO", "K$FO", "@CA", "~DE").HoursBeforeNow(1)
+	if err := query.validate(); err != nil {
+		t.Errorf("unexpected error in lenient mode: %v", err)
+	}
+	if err := query.Strict(true).validate(); err == nil || err.Error() != "invalid station ID(s): K$FO" {
+		t.Errorf("unexpected error in strict mode: %v", err)
+	}
+}
+
+func TestWithin(t *testing.T) {
+	var requests []url.Values
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Query())
+		serveMETARs(w, r)
+	})
+	start := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		query *METARQuery
+		want  string
+	}{
+		{NewMETARQuery().Within(90 * time.Minute), "1.500000"},
+		{NewMETARQuery().Within(15 * time.Minute), "0.250000"},
+		{NewMETARQuery().Within(-2 * time.Hour), "2.000000"},
+		{NewMETARQuery().Within(100 * time.Hour), "72.000000"},
+		{NewMETARQuery().Between(start, start.Add(time.Hour)).Within(90 * time.Minute), "1.500000"},
+	}
+	for i, test := range tests {
+		requests = nil
+		if _, err := client.GetMETAR(test.query); err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+			continue
+		}
+		if len(requests) != 1 {
+			t.Errorf("test %d: expected 1 request, got %d", i, len(requests))
+			continue
+		}
+		if got := requests[0].Get("hoursBeforeNow"); got != test.want {
+			t.Errorf("test %d: got hoursBeforeNow=%q; want %q", i, got, test.want)
+		}
+		if requests[0].Get("startTime") != "" || requests[0].Get("endTime") != "" {
+			t.Errorf("test %d: expected Between to be ignored, got %v", i, requests[0])
+		}
+	}
+}
+
+func TestGetMETARFormats(t *testing.T) {
+	const xmlResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<response>
+  <warnings><warning>partial data</warning></warnings>
+  <data num_results="1">
+    <METAR>
+      <raw_text>KSFO 011256Z 28012G20KT 10SM -RA FEW020 BKN045 15/08 A3002 RMK AO2</raw_text>
+      <station_id>KSFO</station_id>
+      <observation_time>2024-05-01T12:56:00Z</observation_time>
+      <latitude>37.62</latitude>
+      <longitude>-122.37</longitude>
+      <temp_c>15</temp_c>
+      <dewpoint_c>8</dewpoint_c>
+      <wind_dir_degrees>280</wind_dir_degrees>
+      <wind_speed_kt>12</wind_speed_kt>
+      <wind_gust_kt>20</wind_gust_kt>
+      <visibility_statute_mi>10</visibility_statute_mi>
+      <altim_in_hg>30.02</altim_in_hg>
+      <quality_control_flags><auto_station>TRUE</auto_station></quality_control_flags>
+      <wx_string>-RA</wx_string>
+      <sky_condition sky_cover="FEW" cloud_base_ft_agl="2000" />
+      <sky_condition sky_cover="BKN" cloud_base_ft_agl="4500" />
+      <flight_category>VFR</flight_category>
+      <metar_type>METAR</metar_type>
+      <elevation_m>3</elevation_m>
+    </METAR>
+  </data>
+</response>`
+	const jsonResponse = `{
+  "errors": [],
+  "warnings": ["partial data"],
+  "data": [{
+    "raw_text": "KSFO 011256Z 28012G20KT 10SM -RA FEW020 BKN045 15/08 A3002 RMK AO2",
+    "station_id": "KSFO",
+    "observation_time": "2024-05-01T12:56:00Z",
+    "latitude": 37.62,
+    "longitude": -122.37,
+    "temp_c": 15,
+    "dewpoint_c": 8,
+    "wind_dir_degrees": 280,
+    "wind_speed_kt": 12,
+    "wind_gust_kt": 20,
+    "visibility_statute_mi": 10,
+    "altim_in_hg": 30.02,
+    "quality_control_flags": {"auto_station": true},
+    "wx_string": "-RA",
+    "sky_condition": [{"sky_cover": "FEW", "cloud_base_ft_agl": 2000}, {"sky_cover": "BKN", "cloud_base_ft_agl": 4500}],
+    "flight_category": "VFR",
+    "metar_type": "METAR",
+    "elevation_m": 3
+  }]
+}`
+	var formats []string
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		format := r.URL.Query().Get("format")
+		formats = append(formats, format)
+		if format == string(FormatJSON) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(jsonResponse))
+			return
+		}
+		w.Header().Set("Content-Type", "text/xml")
+		w.Write([]byte(xmlResponse))
+	})
+
+	fromXML, err := client.GetMETAR(NewMETARQuery().Station("KSFO").HoursBeforeNow(1))
+	if err != nil {
+		t.Fatalf("XML: unexpected error: %v", err)
+	}
+	fromJSON, err := client.GetMETAR(NewMETARQuery().Station("KSFO").HoursBeforeNow(1).Format(FormatJSON))
+	if err != nil {
+		t.Fatalf("JSON: unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(formats, []string{"xml", "json"}) {
+		t.Errorf("got requested formats %v; want [xml json]", formats)
+	}
+
+	if len(fromXML.METARs) != 1 || len(fromJSON.METARs) != 1 {
+		t.Fatalf("got %d and %d METARs; want 1 each", len(fromXML.METARs), len(fromJSON.METARs))
+	}
+	if !reflect.DeepEqual(fromXML.METARs[0], fromJSON.METARs[0]) {
+		t.Errorf("METARs differ:\nXML:  %+v\nJSON: %+v", fromXML.METARs[0], fromJSON.METARs[0])
+	}
+	if fromXML.METARs[0].WindGustKT != 20 || !fromXML.METARs[0].QualityControlFlags.AutoStation ||
+		len(fromXML.METARs[0].SkyConditions) != 2 {
+		t.Errorf("unexpected METAR: %+v", fromXML.METARs[0])
+	}
+	if !reflect.DeepEqual(fromXML.Warnings, fromJSON.Warnings) {
+		t.Errorf("warnings differ: %q and %q", fromXML.Warnings, fromJSON.Warnings)
+	}
+}
+
+func TestLowercaseStationIDs(t *testing.T) {
+	query := NewMETARQuery().Stations("kord", "Ksfo", "k0co").HoursBeforeNow(1)
+	if problems := query.Validate(); len(problems) != 0 {
+		t.Errorf("unexpected problems for lowercase station IDs: %v", problems)
+	}
+	if err := query.Strict(true).validate(); err != nil {
+		t.Errorf("unexpected error in strict mode: %v", err)
+	}
+	if err := NewMETARQuery().Stations("kord", "k$fo").HoursBeforeNow(1).Strict(true).validate(); err == nil ||
+		err.Error() != "invalid station ID(s): k$fo" {
+		t.Errorf("unexpected error in strict mode: %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package awc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// metarMetrics contains the gauges written by WriteMetrics and the METAR fields they are taken from
+var metarMetrics = []struct {
+	name, help, field string
+}{
+	{"metar_temp_c", "Air temperature in degrees Celsius.", "temp_c"},
+	{"metar_dewpoint_c", "Dew point in degrees Celsius.", "dewpoint_c"},
+	{"metar_wind_dir_degrees", "Direction the wind is blowing from in degrees.", "wind_dir_degrees"},
+	{"metar_wind_kt", "Sustained wind speed in knots.", "wind_speed_kt"},
+	{"metar_wind_gust_kt", "Wind gust speed in knots.", "wind_gust_kt"},
+	{"metar_visibility_sm", "Visibility in statute miles.", "visibility_statute_mi"},
+	{"metar_altimeter_inhg", "Altimeter setting in inches of mercury.", "altim_in_hg"},
+	{"metar_sea_level_pressure_mb", "Sea level pressure in millibars.", "sea_level_pressure_mb"},
+}
+
+var metricsLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// WriteMetrics writes the METARs of the response to w as gauges in the Prometheus text exposition format.
+// Every sample carries the station ID as the station label. The following gauges are written: metar_temp_c,
+// metar_dewpoint_c, metar_wind_dir_degrees, metar_wind_kt, metar_wind_gust_kt, metar_visibility_sm,
+// metar_altimeter_inhg and metar_sea_level_pressure_mb, followed by metar_observation_timestamp_seconds holding the
+// observation time as a Unix timestamp and metar_flight_category, which is 1 for the category label of the flight
+// category of the station.
+// Missing fields are skipped following the rules of WriteCSV. As every series may only be written once, only the
+// latest METAR of every station is considered (see LatestPerStation).
+func (response *METARResponse) WriteMetrics(w io.Writer) error {
+	metars := response.LatestPerStation().METARs
+	writer := bufio.NewWriter(w)
+
+	writeFamily := func(name, help string, samples []string) {
+		if len(samples) == 0 {
+			return
+		}
+		fmt.Fprintf(writer, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+		for _, sample := range samples {
+			fmt.Fprintf(writer, "%s%s\n", name, sample)
+		}
+	}
+	stationLabel := func(metar *METAR) string {
+		return fmt.Sprintf(`station="%s"`, metricsLabelEscaper.Replace(metar.StationID))
+	}
+
+	for _, metric := range metarMetrics {
+		columns, _ := csvColumnsOf(metric.field)
+		var samples []string
+		for _, metar := range metars {
+			if value := columns[0].get(metar); value != "" {
+				samples = append(samples, fmt.Sprintf("{%s} %s", stationLabel(metar), value))
+			}
+		}
+		writeFamily(metric.name, metric.help, samples)
+	}
+
+	var timestamps, categories []string
+	for _, metar := range metars {
+		if observedAt, err := metar.ObservedAt(); err == nil {
+			timestamps = append(timestamps, fmt.Sprintf("{%s} %d", stationLabel(metar), observedAt.Unix()))
+		}
+		if category := metar.flightCategory(); category != "" {
+			categories = append(categories, fmt.Sprintf(`{%s,category="%s"} 1`, stationLabel(metar),
+				metricsLabelEscaper.Replace(category)))
+		}
+	}
+	writeFamily("metar_observation_timestamp_seconds", "Observation time as a Unix timestamp.", timestamps)
+	writeFamily("metar_flight_category", "Flight category of the station.", categories)
+
+	return writer.Flush()
+}
@@ -0,0 +1,79 @@
+package awc
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	response := &METARResponse{METARs: []*METAR{
+		{
+			RawText:         "KSFO 011156Z 28010KT 10SM FEW020 14/08 A3001",
+			StationID:       "KSFO",
+			ObservationTime: "2024-05-01T11:56:00Z",
+			AirTempC:        14, DewPointC: 8, WindDirDegrees: 280, WindSpeedKT: 10,
+			VisibilityStatuteMI: 10, AltimeterInHG: 30.01, FlightCategory: FlightCategoryVFR,
+		},
+		{
+			RawText:         "KSFO 011256Z 28012G20KT 10SM FEW020 15/08 A3002",
+			StationID:       "KSFO",
+			ObservationTime: "2024-05-01T12:56:00.75Z",
+			AirTempC:        15, DewPointC: 8, WindDirDegrees: 280, WindSpeedKT: 12, WindGustKT: 20,
+			VisibilityStatuteMI: 10, AltimeterInHG: 30.02, FlightCategory: FlightCategoryVFR,
+		},
+		{
+			RawText:         `A"B\C 011253Z 2SM BR OVC007 M02/M03 A2992`,
+			StationID:       `A"B\C`,
+			ObservationTime: "2024-05-01T12:53:00Z",
+			AirTempC:        -2, DewPointC: -3, VisibilityStatuteMI: 2, AltimeterInHG: 29.92,
+			SkyConditions: []METARSkyCondition{{"OVC", 700}},
+		},
+	}}
+
+	var buffer bytes.Buffer
+	if err := response.WriteMetrics(&buffer); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `# HELP metar_temp_c Air temperature in degrees Celsius.
+# TYPE metar_temp_c gauge
+metar_temp_c{station="KSFO"} 15
+metar_temp_c{station="A\"B\\C"} -2
+# HELP metar_dewpoint_c Dew point in degrees Celsius.
+# TYPE metar_dewpoint_c gauge
+metar_dewpoint_c{station="KSFO"} 8
+metar_dewpoint_c{station="A\"B\\C"} -3
+# HELP metar_wind_dir_degrees Direction the wind is blowing from in degrees.
+# TYPE metar_wind_dir_degrees gauge
+metar_wind_dir_degrees{station="KSFO"} 280
+# HELP metar_wind_kt Sustained wind speed in knots.
+# TYPE metar_wind_kt gauge
+metar_wind_kt{station="KSFO"} 12
+# HELP metar_wind_gust_kt Wind gust speed in knots.
+# TYPE metar_wind_gust_kt gauge
+metar_wind_gust_kt{station="KSFO"} 20
+# HELP metar_visibility_sm Visibility in statute miles.
+# TYPE metar_visibility_sm gauge
+metar_visibility_sm{station="KSFO"} 10
+metar_visibility_sm{station="A\"B\\C"} 2
+# HELP metar_altimeter_inhg Altimeter setting in inches of mercury.
+# TYPE metar_altimeter_inhg gauge
+metar_altimeter_inhg{station="KSFO"} 30.02
+metar_altimeter_inhg{station="A\"B\\C"} 29.92
+# HELP metar_observation_timestamp_seconds Observation time as a Unix timestamp.
+# TYPE metar_observation_timestamp_seconds gauge
+metar_observation_timestamp_seconds{station="KSFO"} 1714568160
+metar_observation_timestamp_seconds{station="A\"B\\C"} 1714567980
+# HELP metar_flight_category Flight category of the station.
+# TYPE metar_flight_category gauge
+metar_flight_category{station="KSFO",category="VFR"} 1
+metar_flight_category{station="A\"B\\C",category="IFR"} 1
+`
+	if buffer.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", buffer.String(), want)
+	}
+
+	buffer.Reset()
+	if err := new(METARResponse).WriteMetrics(&buffer); err != nil || buffer.Len() != 0 {
+		t.Errorf("expected no output for an empty response, got %q, %v", buffer.String(), err)
+	}
+}
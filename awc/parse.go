@@ -0,0 +1,261 @@
+package awc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	knotsPerMPS = 1.943844
+	// metersPerSM is the amount of meters in a statute mile, used for metric visibilities
+	metersPerSM = 1609.344
+	// cavokVisibilitySM is the visibility implied by CAVOK and '9999', i.e. 10 kilometers
+	cavokVisibilitySM = 10000 / metersPerSM
+)
+
+// ParseMETAR parses a raw METAR report like 'KSFO 011256Z 28012KT 10SM FEW020 15/08 A3002 RMK AO2 SLP165' into a
+// METAR, populating the fields the AWC Text Data Server would derive from it.
+// An optional 'METAR' or 'SPECI' prefix and a trailing '=' are accepted. Wind speeds reported in meters per second or
+// kilometers per hour are converted to knots; use WindUnit to get the original unit. As the report only contains the
+// day of the month, the observation time is resolved to the most recent matching date not after the current time.
+// The coordinates and elevation of the station are not known and thus left at zero.
+// An error is returned if the report lacks the station ID or the observation time.
+// Use ParseMETARAt or Client.ParseMETAR to resolve the observation time against another clock.
+func ParseMETAR(raw string) (*METAR, error) {
+	return ParseMETARAt(raw, time.Now())
+}
+
+// ParseMETAR parses a raw METAR report just like the package-level ParseMETAR does, but resolves the observation time
+// against the clock of the client (see Clock)
+func (client *Client) ParseMETAR(raw string) (*METAR, error) {
+	return ParseMETARAt(raw, client.getNow())
+}
+
+// ParseMETARAt parses a raw METAR report just like ParseMETAR does, but resolves the observation time to the most
+// recent matching date not after now
+func ParseMETARAt(raw string, now time.Time) (*METAR, error) {
+	fields := strings.Fields(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(raw), "=")))
+	metar := &METAR{METARType: "METAR"}
+	if len(fields) > 0 && (fields[0] == "METAR" || fields[0] == "SPECI") {
+		metar.METARType = fields[0]
+		fields = fields[1:]
+	}
+	if len(fields) == 0 || !IsValidStationID(fields[0]) {
+		return nil, errors.New(fmt.Sprintf("invalid METAR: missing station ID: %q", raw))
+	}
+	if len(fields) < 2 {
+		return nil, errors.New(fmt.Sprintf("invalid METAR: missing observation time: %q", raw))
+	}
+	observedAt, err := resolveObservationTime(fields[1], now)
+	if err != nil {
+		return nil, err
+	}
+
+	metar.RawText = strings.Join(fields, " ")
+	metar.StationID = fields[0]
+	metar.ObservationTime = observedAt.Format(time.RFC3339)
+
+	metar.parseWind()
+	metar.parseBody()
+	metar.parseRemarks()
+
+	if temperature, dewPoint, ok := metar.temperatureGroup(); ok {
+		metar.AirTempC = parseWholeDegrees(temperature)
+		metar.DewPointC = parseWholeDegrees(dewPoint)
+	}
+	if temperature, dewPoint, ok := metar.PreciseTemperatures(); ok {
+		metar.AirTempC, metar.DewPointC = temperature, dewPoint
+	}
+	if inHg, _, ok := metar.AltimeterFromRaw(); ok {
+		metar.AltimeterInHG = inHg
+	}
+	metar.FlightCategory = metar.ComputeFlightCategory()
+	return metar, nil
+}
+
+// resolveObservationTime resolves an observation time group like '011256Z' to the most recent matching time not after
+// now (allowing some clock skew)
+func resolveObservationTime(group string, now time.Time) (time.Time, error) {
+	if len(group) != 7 || group[6] != 'Z' || !isDigits(group[:6]) {
+		return time.Time{}, errors.New(fmt.Sprintf("invalid METAR: invalid observation time: %q", group))
+	}
+	day, _ := strconv.Atoi(group[:2])
+	hour, _ := strconv.Atoi(group[2:4])
+	minute, _ := strconv.Atoi(group[4:6])
+	if day < 1 || day > 31 || hour > 23 || minute > 59 {
+		return time.Time{}, errors.New(fmt.Sprintf("invalid METAR: invalid observation time: %q", group))
+	}
+
+	now = now.UTC()
+	latest := now.Add(time.Hour)
+	for months := 0; months < 12; months++ {
+		candidate := time.Date(now.Year(), now.Month()-time.Month(months), day, hour, minute, 0, 0, time.UTC)
+		if candidate.Day() == day && !candidate.After(latest) {
+			return candidate, nil
+		}
+	}
+	return time.Time{}, errors.New(fmt.Sprintf("invalid METAR: invalid observation time: %q", group))
+}
+
+// parseWholeDegrees parses a whole-degree temperature like '05' or 'M12' as returned by temperatureGroup
+func parseWholeDegrees(value string) float32 {
+	negative := strings.HasPrefix(value, "M")
+	degrees, _ := strconv.Atoi(strings.TrimPrefix(value, "M"))
+	if negative {
+		degrees = -degrees
+	}
+	return float32(degrees)
+}
+
+// parseWind populates the wind fields, converting the speeds to knots
+func (metar *METAR) parseWind() {
+	group, ok := metar.windGroup()
+	if !ok {
+		return
+	}
+	values, _ := parseWindGroup(group)
+
+	toKnots := func(speed int) int {
+		switch values.unit {
+		case "MPS":
+			return int(math.Round(float64(speed) * knotsPerMPS))
+		case "KMH":
+			return int(math.Round(float64(speed) / kmhPerKnot))
+		default:
+			return speed
+		}
+	}
+	metar.WindDirDegrees = values.direction
+	metar.WindSpeedKT = toKnots(values.speed)
+	metar.WindGustKT = toKnots(values.gust)
+}
+
+// parseBody populates the visibility, weather, sky condition and report modifier fields from the body groups
+func (metar *METAR) parseBody() {
+	groups := metar.body()
+	if len(groups) > 0 && (groups[0] == "METAR" || groups[0] == "SPECI") {
+		groups = groups[1:]
+	}
+	if len(groups) < 2 {
+		return
+	}
+
+	var weather []string
+	visibilityParsed := false
+	for i := 2; i < len(groups); i++ {
+		group := groups[i]
+		switch {
+		case group == "AUTO":
+			metar.QualityControlFlags.Auto = true
+		case group == "COR" || (len(group) == 3 && strings.HasPrefix(group, "CC")):
+			metar.QualityControlFlags.Corrected = true
+		case group == "CAVOK":
+			metar.VisibilityStatuteMI = cavokVisibilitySM
+			metar.SkyConditions = append(metar.SkyConditions, METARSkyCondition{SkyCover: "CAVOK"})
+			visibilityParsed = true
+		case !visibilityParsed && (len(group) == 4 || strings.HasSuffix(group, "NDV")) &&
+			isDigits(strings.TrimSuffix(group, "NDV")):
+			meters, _ := strconv.Atoi(strings.TrimSuffix(group, "NDV"))
+			if meters == 9999 {
+				metar.VisibilityStatuteMI = cavokVisibilitySM
+			} else {
+				metar.VisibilityStatuteMI = float32(meters) / metersPerSM
+			}
+			visibilityParsed = true
+		case !visibilityParsed && strings.HasSuffix(group, "SM"):
+			visibility, _, _, err := parseStatuteMiles(group)
+			if err == nil {
+				metar.VisibilityStatuteMI = visibility
+				visibilityParsed = true
+			}
+		case !visibilityParsed && len(group) == 1 && isDigits(group) && i+1 < len(groups) &&
+			strings.HasSuffix(groups[i+1], "SM"):
+			visibility, _, _, err := parseStatuteMiles(group + " " + groups[i+1])
+			if err == nil {
+				metar.VisibilityStatuteMI = visibility
+				visibilityParsed = true
+				i++
+			}
+		case group == "SKC" || group == "CLR" || group == "NSC" || group == "NCD":
+			cover := group
+			if cover == "NCD" {
+				cover = "NSC"
+			}
+			metar.SkyConditions = append(metar.SkyConditions, METARSkyCondition{SkyCover: cover})
+		default:
+			if condition, ok := parseSkyConditionGroup(group); ok {
+				if condition.SkyCover == "OVX" {
+					metar.VerticalVisibilityFT = condition.CloudBaseFTAGL
+				}
+				metar.SkyConditions = append(metar.SkyConditions, condition)
+				continue
+			}
+			if _, ok := parseWindGroup(group); ok || strings.HasPrefix(group, "RE") {
+				continue
+			}
+			phenomenon, ok := decodeWeatherGroup(group)
+			if ok && (len(phenomenon.Phenomena) > 0 || phenomenon.Descriptor != "") {
+				weather = append(weather, group)
+			}
+		}
+	}
+	metar.WXString = strings.Join(weather, " ")
+}
+
+// parseSkyConditionGroup parses a sky condition group like 'BKN015', 'OVC030CB', 'FEW///' or 'VV002'
+func parseSkyConditionGroup(group string) (METARSkyCondition, bool) {
+	cover, rest := "", ""
+	switch {
+	case strings.HasPrefix(group, "VV"):
+		cover, rest = "OVX", group[2:]
+	case len(group) >= 6:
+		cover, rest = group[:3], group[3:]
+		if ParseSkyCover(cover) < SkyCoverFEW || cover == "OVX" {
+			return METARSkyCondition{}, false
+		}
+	default:
+		return METARSkyCondition{}, false
+	}
+	if len(rest) < 3 {
+		return METARSkyCondition{}, false
+	}
+	switch rest[3:] {
+	case "", "CB", "TCU", "///":
+	default:
+		return METARSkyCondition{}, false
+	}
+
+	condition := METARSkyCondition{SkyCover: cover}
+	if isDigits(rest[:3]) {
+		base, _ := strconv.Atoi(rest[:3])
+		condition.CloudBaseFTAGL = base * 100
+	} else if rest[:3] != "///" {
+		return METARSkyCondition{}, false
+	}
+	return condition, true
+}
+
+// parseRemarks populates the quality control flags and the sea level pressure from the remarks
+func (metar *METAR) parseRemarks() {
+	for _, group := range metar.remarks() {
+		switch group {
+		case "AO1", "AO2", "AO1A", "AO2A":
+			metar.QualityControlFlags.AutoStation = true
+		case "$":
+			metar.QualityControlFlags.MaintenanceIndicator = true
+		case "TSNO":
+			metar.QualityControlFlags.LightningSensorOff = true
+		case "FZRANO":
+			metar.QualityControlFlags.FreezingRainSensorOff = true
+		case "PWINO":
+			metar.QualityControlFlags.PresentWeatherSensorOff = true
+		}
+	}
+	if pressure, ok := metar.SeaLevelPressure(); ok {
+		metar.SeaLevelPressureMB = pressure
+	}
+}
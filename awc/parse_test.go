@@ -0,0 +1,97 @@
+package awc
+
+import (
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2024, time.May, 1, 14, 0, 0, 0, time.UTC)
+
+func TestParseMETARAtWindUnits(t *testing.T) {
+	tests := []struct {
+		raw              string
+		speedKT, gustKT  int
+		unit             string
+		directionDegrees int
+		variable, calm   bool
+	}{
+		{raw: "KSFO 011256Z 28012KT 10SM FEW020 15/08 A3002", speedKT: 12, unit: "KT", directionDegrees: 280},
+		{raw: "UUEE 011230Z 27012MPS CAVOK 15/08 Q1013", speedKT: 23, unit: "MPS", directionDegrees: 270},
+		{raw: "UUEE 011230Z 27005G10MPS 9999 SCT030 15/08 Q1013", speedKT: 10, gustKT: 19, unit: "MPS",
+			directionDegrees: 270},
+		{raw: "ZBAA 011230Z 09036KMH 9999 NSC 15/08 Q1013", speedKT: 19, unit: "KMH", directionDegrees: 90},
+		{raw: "UUEE 011230Z VRB02MPS CAVOK 15/08 Q1013", speedKT: 4, unit: "MPS", variable: true},
+		{raw: "UUEE 011230Z 00000MPS CAVOK 15/08 Q1013", unit: "MPS", calm: true},
+	}
+	for _, test := range tests {
+		metar, err := ParseMETARAt(test.raw, testNow)
+		if err != nil {
+			t.Errorf("ParseMETARAt(%q) failed: %v", test.raw, err)
+			continue
+		}
+		if metar.WindSpeedKT != test.speedKT || metar.WindGustKT != test.gustKT ||
+			metar.WindDirDegrees != test.directionDegrees {
+			t.Errorf("ParseMETARAt(%q): got wind %d/%dG%d; want %d/%dG%d", test.raw, metar.WindDirDegrees,
+				metar.WindSpeedKT, metar.WindGustKT, test.directionDegrees, test.speedKT, test.gustKT)
+		}
+		if unit := metar.WindUnit(); unit != test.unit {
+			t.Errorf("ParseMETARAt(%q): got unit %q; want %q", test.raw, unit, test.unit)
+		}
+		if metar.IsVariableWind() != test.variable || metar.IsCalm() != test.calm {
+			t.Errorf("ParseMETARAt(%q): got variable %t and calm %t", test.raw, metar.IsVariableWind(), metar.IsCalm())
+		}
+	}
+}
+
+func TestParseMETARAtObservationTime(t *testing.T) {
+	tests := []struct {
+		raw  string
+		now  time.Time
+		want string
+	}{
+		{"KSFO 011256Z 28012KT 10SM", testNow, "2024-05-01T12:56:00Z"},
+		{"KSFO 011456Z 28012KT 10SM", testNow, "2024-05-01T14:56:00Z"},
+		{"KSFO 301756Z 28012KT 10SM", testNow, "2024-04-30T17:56:00Z"},
+		{"KSFO 312356Z 28012KT 10SM", time.Date(2024, time.March, 1, 0, 10, 0, 0, time.UTC), "2024-01-31T23:56:00Z"},
+		{"KSFO 312356Z 28012KT 10SM", time.Date(2025, time.January, 1, 0, 5, 0, 0, time.UTC), "2024-12-31T23:56:00Z"},
+	}
+	for _, test := range tests {
+		metar, err := ParseMETARAt(test.raw, test.now)
+		if err != nil {
+			t.Errorf("ParseMETARAt(%q) failed: %v", test.raw, err)
+			continue
+		}
+		if metar.ObservationTime != test.want {
+			t.Errorf("ParseMETARAt(%q, %s): got %s; want %s", test.raw, test.now, metar.ObservationTime, test.want)
+		}
+	}
+}
+
+func TestParseMETARAtRejectsMalformedReports(t *testing.T) {
+	for _, raw := range []string{"", "METAR", "KSFO", "KSFO 011256 28012KT", "KSFO 321256Z 28012KT", "12 011256Z"} {
+		if _, err := ParseMETARAt(raw, testNow); err == nil {
+			t.Errorf("ParseMETARAt(%q): expected an error", raw)
+		}
+	}
+	for _, raw := range []string{"KSFO 011256Z 12G45KT 10SM", "KSFO 011256Z G1234KT", "KSFO 011256Z KT"} {
+		metar, err := ParseMETARAt(raw, testNow)
+		if err != nil {
+			t.Errorf("ParseMETARAt(%q) failed: %v", raw, err)
+		} else if !metar.IsWindMissing() {
+			t.Errorf("ParseMETARAt(%q): expected missing wind", raw)
+		}
+	}
+}
+
+func TestClientParseMETARUsesClock(t *testing.T) {
+	client := new(Client).Clock(func() time.Time {
+		return time.Date(2023, time.February, 3, 4, 0, 0, 0, time.UTC)
+	})
+	metar, err := client.ParseMETAR("METAR KSFO 030356Z 28012KT 10SM=")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if metar.ObservationTime != "2023-02-03T03:56:00Z" || metar.METARType != "METAR" {
+		t.Errorf("unexpected METAR: %+v", metar)
+	}
+}
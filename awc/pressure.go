@@ -0,0 +1,26 @@
+package awc
+
+import "strconv"
+
+// AltimeterFromRaw parses the altimeter setting of the raw METAR text and returns it in both inHg and hPa.
+// Both the inHg notation in hundredths (e.g. 'A2992' for 29.92 inHg) and the hPa notation (e.g. 'Q1013') are supported;
+// the respective other unit is converted.
+// ok is false if the raw text contains no altimeter group.
+func (metar *METAR) AltimeterFromRaw() (inHg float32, hPa float32, ok bool) {
+	for _, group := range metar.body() {
+		if len(group) != 5 || !isDigits(group[1:]) {
+			continue
+		}
+
+		value, _ := strconv.Atoi(group[1:])
+		switch group[0] {
+		case 'A':
+			inHg = float32(value) / 100
+			return inHg, inHg * hPaPerInHG, true
+		case 'Q':
+			hPa = float32(value)
+			return hPa / hPaPerInHG, hPa, true
+		}
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,28 @@
+package awc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAltimeterFromRaw(t *testing.T) {
+	tests := []struct {
+		raw       string
+		inHg, hPa float64
+		ok        bool
+	}{
+		{"KSFO 011256Z 28012KT 10SM FEW020 15/08 A2992 RMK AO2", 29.92, 1013.2, true},
+		{"KORD 011251Z 27012KT 10SM BKN025 M06/M12 A3045", 30.45, 1031.2, true},
+		{"EDDF 011250Z 28012KT 9999 FEW020 15/08 Q1013 NOSIG", 29.91, 1013, true},
+		{"EGLL 011250Z 27005KT 9999 SCT030 12/08 Q0987", 29.15, 987, true},
+		{"EDDF 011250Z 28012KT 9999 FEW020 15/08 Q//// NOSIG", 0, 0, false},
+		{"KSFO 011256Z 28012KT 10SM FEW020 15/08 RMK AO2", 0, 0, false},
+	}
+	for _, test := range tests {
+		inHg, hPa, ok := (&METAR{RawText: test.raw}).AltimeterFromRaw()
+		if ok != test.ok || math.Abs(float64(inHg)-test.inHg) > 0.005 || math.Abs(float64(hPa)-test.hPa) > 0.05 {
+			t.Errorf("AltimeterFromRaw(%q) = %v, %v, %t; want %v, %v, %t", test.raw, inHg, hPa, ok, test.inHg,
+				test.hPa, test.ok)
+		}
+	}
+}
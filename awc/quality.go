@@ -0,0 +1,256 @@
+package awc
+
+import "strings"
+
+// Completeness returns the fraction (0 to 1) of the key fields the METAR contains.
+// The key fields and their presence detection are:
+//   - wind: present unless IsWindMissing reports true
+//   - temperature and dew point: present if the respective half of the temperature group (e.g. 'M05/M12') is
+//     reported; if the raw text is absent, a non-zero AirTempC or DewPointC is required
+//   - visibility: present if VisibilityStatuteMI is greater than zero
+//   - altimeter: present if AltimeterInHG is greater than zero
+//   - sky: present if at least one sky condition is reported
+func (metar *METAR) Completeness() float32 {
+	temperature, dewPoint := metar.hasTemperatures()
+	present := []bool{
+		!metar.IsWindMissing(),
+		temperature,
+		dewPoint,
+		metar.VisibilityStatuteMI > 0,
+		metar.AltimeterInHG > 0,
+		len(metar.SkyConditions) > 0,
+	}
+
+	count := 0
+	for _, isPresent := range present {
+		if isPresent {
+			count++
+		}
+	}
+	return float32(count) / float32(len(present))
+}
+
+// EstimatedFields returns the names of the fields the raw text marks as estimated.
+// The following rules are applied:
+//   - "wind" for the 'WND DATA ESTMD' remark
+//   - "altimeter" for the 'ALSTG ESTMD' or 'ALSTG/SLP ESTMD' remarks
+//   - "sea_level_pressure" for the 'SLP ESTMD' or 'ALSTG/SLP ESTMD' remarks
+//   - "ceiling" for a sky condition group carrying the 'E' (estimated) ceiling designator, e.g. 'E025BKN'
+func (metar *METAR) EstimatedFields() []string {
+	var fields []string
+	addField := func(field string) {
+		fields = appendUnique(fields, field)
+	}
+
+	remarks := metar.remarks()
+	for i := 1; i < len(remarks); i++ {
+		if remarks[i] != "ESTMD" {
+			continue
+		}
+		switch remarks[i-1] {
+		case "ALSTG":
+			addField("altimeter")
+		case "SLP":
+			addField("sea_level_pressure")
+		case "ALSTG/SLP":
+			addField("altimeter")
+			addField("sea_level_pressure")
+		case "DATA":
+			if i >= 2 && remarks[i-2] == "WND" {
+				addField("wind")
+			}
+		}
+	}
+
+	for _, group := range metar.body() {
+		if len(group) >= 7 && group[0] == 'E' && isDigits(group[1:4]) && ParseSkyCover(group[4:]).isCeiling() {
+			addField("ceiling")
+		}
+	}
+
+	return fields
+}
+
+// HasEstimatedData reports whether the raw text marks any field as estimated.
+// Please refer to EstimatedFields for the detection rules.
+func (metar *METAR) HasEstimatedData() bool {
+	return len(metar.EstimatedFields()) > 0
+}
+
+// MissingFields returns the names of the fields the raw text explicitly reports as missing using slashes.
+// The following rules are applied:
+//   - "wind" for a wind group consisting of slashes, e.g. '/////KT'
+//   - "visibility" for a visibility group consisting of slashes, e.g. '////' or '////SM'
+//   - "weather" for the '//' present weather group
+//   - "sky" for a sky condition group with slashes instead of a cover or base, e.g. '//////' or 'BKN///'
+//   - "temperature" and "dewpoint" for the respective half of the temperature group, e.g. '12///'
+//   - "altimeter" for an altimeter group consisting of slashes, e.g. 'A////' or 'Q////'
+func (metar *METAR) MissingFields() []string {
+	var fields []string
+	addField := func(field string) {
+		fields = appendUnique(fields, field)
+	}
+	for _, group := range metar.body() {
+		if !strings.Contains(group, "/") {
+			continue
+		}
+
+		if temperature, dewPoint, ok := splitTemperatureGroup(group); ok {
+			if temperature == "" {
+				addField("temperature")
+			}
+			if dewPoint == "" {
+				addField("dewpoint")
+			}
+			continue
+		}
+
+		trimmed := strings.Trim(group, "/")
+		switch {
+		case group == "//":
+			addField("weather")
+		case group == "////" || trimmed == "SM":
+			addField("visibility")
+		case trimmed == "KT" || trimmed == "MPS" || trimmed == "KMH":
+			addField("wind")
+		case trimmed == "A" || trimmed == "Q":
+			addField("altimeter")
+		case trimmed == "" || trimmed == "CB" || trimmed == "TCU" || ParseSkyCover(trimmed) != SkyCoverUnknown ||
+			trimmed == "VV":
+			addField("sky")
+		}
+	}
+	return fields
+}
+
+// WeatherReliable reports whether the present weather of the METAR (WXString and thus Weather) can be relied on.
+// This is not the case if the present weather sensor is off or the station reported no signal, as the absence of
+// weather is meaningless then.
+func (metar *METAR) WeatherReliable() bool {
+	return !metar.QualityControlFlags.PresentWeatherSensorOff && !metar.QualityControlFlags.NoSignal
+}
+
+// reportModifiers returns the report modifier groups following the observation time of the raw METAR text, e.g. 'AUTO'
+// or 'COR'
+func (metar *METAR) reportModifiers() []string {
+	groups := metar.body()
+	if len(groups) > 0 && (groups[0] == "METAR" || groups[0] == "SPECI") {
+		groups = groups[1:]
+	}
+	if len(groups) < 2 {
+		return nil
+	}
+
+	var modifiers []string
+	for _, group := range groups[2:] {
+		switch {
+		case group == "AUTO" || group == "COR" || group == "NIL" || group == "RTD":
+		case len(group) == 3 && strings.HasPrefix(group, "CC") && group[2] >= 'A' && group[2] <= 'Z':
+		default:
+			return modifiers
+		}
+		modifiers = append(modifiers, group)
+	}
+	return modifiers
+}
+
+// Modifiers returns the report modifiers following the observation time of the raw METAR text in the order they were
+// reported, i.e. 'AUTO' (fully automated), 'COR' or 'CCx' (corrected), 'NIL' (missing report) and 'RTD' (delayed).
+// Use IsAutomated and IsCorrected to reconcile them with the quality control flags.
+func (metar *METAR) Modifiers() []string {
+	return metar.reportModifiers()
+}
+
+// IsCorrected reports whether the METAR is a correction of a previously issued report.
+// Just like for IsAutomated, the raw text takes precedence: if it is present, the report is corrected if and only if
+// it carries the 'COR' or a 'CCx' modifier. Otherwise the Corrected quality control flag is used.
+func (metar *METAR) IsCorrected() bool {
+	if metar.RawText == "" {
+		return metar.QualityControlFlags.Corrected
+	}
+	for _, modifier := range metar.reportModifiers() {
+		if modifier == "COR" || strings.HasPrefix(modifier, "CC") {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAutomated reports whether the METAR is a fully automated observation, i.e. one without human augmentation.
+// The raw text takes precedence, as it is the report itself: if it is present, the observation is automated if and only
+// if it carries the 'AUTO' modifier. Otherwise the Auto quality control flag is used.
+// Please keep in mind that the AutoStation flag (AO1/AO2 remark) only reports that the station is automated, which
+// does not rule out augmentation by an observer, and is thus not considered.
+func (metar *METAR) IsAutomated() bool {
+	if metar.RawText != "" {
+		return containsString(metar.reportModifiers(), "AUTO")
+	}
+	return metar.QualityControlFlags.Auto
+}
+
+// The bits of the bitmask representation of METARQualityControlFlags.
+// The assignments are stable; new flags will only ever be appended.
+const (
+	QualityFlagCorrected uint = 1 << iota
+	QualityFlagAuto
+	QualityFlagAutoStation
+	QualityFlagMaintenanceIndicator
+	QualityFlagNoSignal
+	QualityFlagLightningSensorOff
+	QualityFlagFreezingRainSensorOff
+	QualityFlagPresentWeatherSensorOff
+)
+
+// NewMETARQualityControlFlags creates METARQualityControlFlags from their bitmask representation as returned by Flags.
+// Unknown bits are ignored.
+func NewMETARQualityControlFlags(bits uint) METARQualityControlFlags {
+	return METARQualityControlFlags{
+		Corrected:               bits&QualityFlagCorrected != 0,
+		Auto:                    bits&QualityFlagAuto != 0,
+		AutoStation:             bits&QualityFlagAutoStation != 0,
+		MaintenanceIndicator:    bits&QualityFlagMaintenanceIndicator != 0,
+		NoSignal:                bits&QualityFlagNoSignal != 0,
+		LightningSensorOff:      bits&QualityFlagLightningSensorOff != 0,
+		FreezingRainSensorOff:   bits&QualityFlagFreezingRainSensorOff != 0,
+		PresentWeatherSensorOff: bits&QualityFlagPresentWeatherSensorOff != 0,
+	}
+}
+
+// Flags returns the bitmask representation of the flags using the QualityFlag constants
+func (flags METARQualityControlFlags) Flags() uint {
+	var bits uint
+	for _, flag := range []struct {
+		bit uint
+		set bool
+	}{
+		{QualityFlagCorrected, flags.Corrected},
+		{QualityFlagAuto, flags.Auto},
+		{QualityFlagAutoStation, flags.AutoStation},
+		{QualityFlagMaintenanceIndicator, flags.MaintenanceIndicator},
+		{QualityFlagNoSignal, flags.NoSignal},
+		{QualityFlagLightningSensorOff, flags.LightningSensorOff},
+		{QualityFlagFreezingRainSensorOff, flags.FreezingRainSensorOff},
+		{QualityFlagPresentWeatherSensorOff, flags.PresentWeatherSensorOff},
+	} {
+		if flag.set {
+			bits |= flag.bit
+		}
+	}
+	return bits
+}
+
+// HasAll reports whether all flags of the given bitmask are set, e.g. HasAll(QualityFlagAuto|QualityFlagNoSignal)
+func (flags METARQualityControlFlags) HasAll(mask uint) bool {
+	return flags.Flags()&mask == mask
+}
+
+// HasAny reports whether any flag of the given bitmask is set
+func (flags METARQualityControlFlags) HasAny(mask uint) bool {
+	return flags.Flags()&mask != 0
+}
+
+// HasSensorOutage reports whether any sensor of the station is reported to be off or the station has no signal
+func (flags METARQualityControlFlags) HasSensorOutage() bool {
+	return flags.HasAny(QualityFlagNoSignal | QualityFlagLightningSensorOff | QualityFlagFreezingRainSensorOff |
+		QualityFlagPresentWeatherSensorOff)
+}
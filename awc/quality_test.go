@@ -0,0 +1,74 @@
+package awc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEstimatedFields(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want []string
+	}{
+		{"KSFO 011256Z 28012KT 10SM E025BKN 15/08 A3002 RMK AO2", []string{"ceiling"}},
+		{"KSFO 011256Z 28012KT 10SM BKN025 15/08 A3002 RMK AO2 WND DATA ESTMD", []string{"wind"}},
+		{"KSFO 011256Z 28012KT 10SM BKN025 15/08 A3002 RMK AO2 ALSTG/SLP ESTMD",
+			[]string{"altimeter", "sea_level_pressure"}},
+		{"KSFO 011256Z 28012KT 10SM BKN025 15/08 A3002 RMK SLP ESTMD ALSTG ESTMD",
+			[]string{"sea_level_pressure", "altimeter"}},
+		{"KSFO 011256Z 28012KT 10SM BKN025 15/08 A3002 RMK AO2 SLP165", nil},
+	}
+	for _, test := range tests {
+		metar := &METAR{RawText: test.raw}
+		if got := metar.EstimatedFields(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("EstimatedFields(%q) = %q; want %q", test.raw, got, test.want)
+		}
+		if got := metar.HasEstimatedData(); got != (len(test.want) > 0) {
+			t.Errorf("HasEstimatedData(%q) = %t", test.raw, got)
+		}
+	}
+}
+
+func TestMissingFields(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want []string
+	}{
+		{"KSFO 011256Z /////KT 10SM BKN025 15/08 A3002", []string{"wind"}},
+		{"KSFO 011256Z 28012KT ////SM BKN025 15/08 A3002", []string{"visibility"}},
+		{"EDDF 011250Z 28012KT //// // ////// 15/08 Q////", []string{"visibility", "weather", "sky", "altimeter"}},
+		{"KSFO 011256Z 28012KT 10SM BKN/// 15/// A3002", []string{"sky", "dewpoint"}},
+		{"KSFO 011256Z 28012KT 10SM BKN025 ///08 A////", []string{"temperature", "altimeter"}},
+		{"KSFO 011256Z 28012KT 10SM BKN025 15/08 A3002 RMK AO2", nil},
+	}
+	for _, test := range tests {
+		if got := (&METAR{RawText: test.raw}).MissingFields(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("MissingFields(%q) = %q; want %q", test.raw, got, test.want)
+		}
+	}
+}
+
+func TestIsAutomated(t *testing.T) {
+	tests := []struct {
+		name  string
+		metar *METAR
+		want  bool
+	}{
+		{"AUTO modifier", &METAR{RawText: "KSFO 011256Z AUTO 28012KT 10SM CLR 15/08 A3002 RMK AO2"}, true},
+		{"augmented AO2 station", &METAR{RawText: "KSFO 011256Z 28012KT 10SM CLR 15/08 A3002 RMK AO2",
+			QualityControlFlags: METARQualityControlFlags{AutoStation: true}}, false},
+		{"raw text wins over flags", &METAR{RawText: "KSFO 011256Z 28012KT 10SM CLR 15/08 A3002",
+			QualityControlFlags: METARQualityControlFlags{Auto: true}}, false},
+		{"raw text wins over missing flags", &METAR{RawText: "METAR KSFO 011256Z AUTO 28012KT 10SM"}, true},
+		{"AUTO after COR", &METAR{RawText: "KSFO 011256Z COR AUTO 28012KT 10SM"}, true},
+		{"AUTO in remarks only", &METAR{RawText: "KSFO 011256Z 28012KT 10SM RMK AUTO"}, false},
+		{"Auto flag without raw text", &METAR{QualityControlFlags: METARQualityControlFlags{Auto: true}}, true},
+		{"AutoStation flag without raw text", &METAR{
+			QualityControlFlags: METARQualityControlFlags{AutoStation: true}}, false},
+	}
+	for _, test := range tests {
+		if got := test.metar.IsAutomated(); got != test.want {
+			t.Errorf("%s: IsAutomated() = %t; want %t", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package awc
+
+import (
+	"strconv"
+	"strings"
+)
+
+// body returns the whitespace-separated groups of the raw METAR text preceding the trend forecast and the 'RMK' token
+func (metar *METAR) body() []string {
+	fields := strings.Fields(metar.RawText)
+	for i, field := range fields {
+		if field == "RMK" || isTrendIndicator(field) {
+			return fields[:i]
+		}
+	}
+	return fields
+}
+
+// remarks returns the whitespace-separated groups following the 'RMK' token of the raw METAR text
+func (metar *METAR) remarks() []string {
+	fields := strings.Fields(metar.RawText)
+	for i, field := range fields {
+		if field == "RMK" {
+			return fields[i+1:]
+		}
+	}
+	return nil
+}
+
+// isDigits reports whether value is non-empty and consists of ASCII digits only
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, char := range value {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// IsTracePrecipitation reports whether the METAR remarks contain a trace amount of precipitation.
+// The numeric precipitation fields can not distinguish a trace from a real small amount, so this is detected from the
+// raw text instead: a precipitation group consisting of only zeros ('P0000' for the last hour, '60000' for the last 3
+// or 6 hours and '70000' for the last 24 hours) denotes a trace.
+func (metar *METAR) IsTracePrecipitation() bool {
+	for _, group := range metar.remarks() {
+		switch group {
+		case "P0000", "60000", "70000":
+			return true
+		}
+	}
+	return false
+}
+
+// PressureTendency parses the 3-hourly pressure tendency remark ('5appp') of the METAR.
+// The returned characteristic is either "rising", "steady" or "falling" and is derived from the tendency code 'a'.
+// The returned change is signed, meaning it is negative if the pressure fell.
+// ok is false if the remark is absent or malformed.
+func (metar *METAR) PressureTendency() (characteristic string, changeMB float32, ok bool) {
+	for _, group := range metar.remarks() {
+		if len(group) != 5 || group[0] != '5' || !isDigits(group) {
+			continue
+		}
+
+		code := group[1] - '0'
+		change, _ := strconv.Atoi(group[2:])
+		changeMB = float32(change) / 10
+
+		switch {
+		case code <= 3:
+			return "rising", changeMB, true
+		case code == 4:
+			return "steady", 0, true
+		case code <= 8:
+			return "falling", -changeMB, true
+		}
+	}
+	return "", 0, false
+}
+
+// SeaLevelPressure returns the sea-level pressure in hPa (mb).
+// The SeaLevelPressureMB field is preferred; if it is not set, the 'SLPppp' remark is parsed instead.
+// As the remark only contains the last three digits in tenths of hPa, values of 500 and above are interpreted as
+// 9pp.p hPa and values below 500 as 10pp.p hPa, e.g. 'SLP992' denotes 999.2 hPa and 'SLP132' denotes 1013.2 hPa.
+func (metar *METAR) SeaLevelPressure() (float32, bool) {
+	if metar.SeaLevelPressureMB != 0 {
+		return metar.SeaLevelPressureMB, true
+	}
+
+	for _, group := range metar.remarks() {
+		digits := strings.TrimPrefix(group, "SLP")
+		if len(digits) != 3 || digits == group || !isDigits(digits) {
+			continue
+		}
+
+		value, _ := strconv.Atoi(digits)
+		if value >= 500 {
+			return 900 + float32(value)/10, true
+		}
+		return 1000 + float32(value)/10, true
+	}
+	return 0, false
+}
+
+// parseSignedTenths parses a remark temperature consisting of a sign digit (1 = negative) followed by three digits in
+// tenths of a degree, e.g. "1056" for -5.6
+func parseSignedTenths(value string) (float32, bool) {
+	if len(value) != 4 || (value[0] != '0' && value[0] != '1') || !isDigits(value) {
+		return 0, false
+	}
+	tenths, _ := strconv.Atoi(value[1:])
+	if value[0] == '1' {
+		tenths = -tenths
+	}
+	return float32(tenths) / 10, true
+}
+
+// PreciseTemperatures parses the hourly temperature and dew point remark ('TsTTTsDDD') of the METAR, which provides
+// both values with a precision of a tenth of a degree Celsius, e.g. 'T10561122' denotes -5.6 °C and -12.2 °C.
+// ok is false if the remark is absent or malformed.
+func (metar *METAR) PreciseTemperatures() (tempC, dewpointC float32, ok bool) {
+	for _, group := range metar.remarks() {
+		if len(group) != 9 || group[0] != 'T' {
+			continue
+		}
+
+		temperature, temperatureOK := parseSignedTenths(group[1:5])
+		dewPoint, dewPointOK := parseSignedTenths(group[5:])
+		if temperatureOK && dewPointOK {
+			return temperature, dewPoint, true
+		}
+	}
+	return 0, 0, false
+}
+
+// SixHourExtremes parses the 6-hourly maximum ('1sTTT') and minimum ('2sTTT') temperature remarks of the METAR, which
+// provide both values with a precision of a tenth of a degree Celsius, e.g. '10142 21001' denotes 14.2 °C and
+// -0.1 °C.
+// These remarks are only reported at the synoptic hours and may be present even if MaxAirTemp6HC and MinAirTemp6HC are
+// not. ok is false unless both remarks are present and well-formed.
+func (metar *METAR) SixHourExtremes() (maxC, minC float32, ok bool) {
+	var maxOK, minOK bool
+	for _, group := range metar.remarks() {
+		if len(group) != 5 {
+			continue
+		}
+		switch group[0] {
+		case '1':
+			if !maxOK {
+				maxC, maxOK = parseSignedTenths(group[1:])
+			}
+		case '2':
+			if !minOK {
+				minC, minOK = parseSignedTenths(group[1:])
+			}
+		}
+	}
+	if !maxOK || !minOK {
+		return 0, 0, false
+	}
+	return maxC, minC, true
+}
@@ -0,0 +1,81 @@
+package awc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPreciseTemperatures(t *testing.T) {
+	tests := []struct {
+		raw              string
+		tempC, dewpointC float32
+		ok               bool
+	}{
+		{"KSFO 011256Z 28012KT 10SM 15/08 A3002 RMK AO2 SLP165 T01500083", 15.0, 8.3, true},
+		{"KORD 011251Z 27012KT 10SM M06/M12 A3002 RMK AO2 T10561122", -5.6, -12.2, true},
+		{"KDEN 011253Z 27012KT 10SM 00/M01 A3002 RMK AO2 T10021011", -0.2, -1.1, true},
+		{"KPHX 011251Z 27012KT 10SM 41/M02 A2990 RMK AO2 T04061017", 40.6, -1.7, true},
+		{"KSFO 011256Z 28012KT 10SM 15/08 A3002 RMK AO2 T0150", 0, 0, false},
+		{"KSFO 011256Z 28012KT 10SM 15/08 A3002 RMK AO2 T21500083", 0, 0, false},
+		{"KSFO 011256Z 28012KT 10SM 15/08 A3002 RMK AO2", 0, 0, false},
+		{"KSFO 011256Z 28012KT 10SM 15/08 A3002", 0, 0, false},
+	}
+	for _, test := range tests {
+		tempC, dewpointC, ok := (&METAR{RawText: test.raw}).PreciseTemperatures()
+		if ok != test.ok || tempC != test.tempC || dewpointC != test.dewpointC {
+			t.Errorf("PreciseTemperatures(%q) = %v, %v, %t; want %v, %v, %t", test.raw, tempC, dewpointC, ok,
+				test.tempC, test.dewpointC, test.ok)
+		}
+	}
+}
+
+func TestSixHourExtremes(t *testing.T) {
+	tests := []struct {
+		raw        string
+		maxC, minC float32
+		ok         bool
+	}{
+		{"KSFO 011156Z 28012KT 10SM 15/08 A3002 RMK AO2 SLP165 10142 21001 T01500083", 14.2, -0.1, true},
+		{"KORD 011151Z 27012KT 10SM M06/M12 A3002 RMK AO2 11033 21078 T10561122", -3.3, -7.8, true},
+		{"KPHX 012351Z 27012KT 10SM 41/M02 A2990 RMK AO2 10433 20256", 43.3, 25.6, true},
+		{"KSFO 011156Z 28012KT 10SM 15/08 A3002 RMK AO2 10142", 0, 0, false},
+		{"KSFO 011156Z 28012KT 10SM 15/08 A3002 RMK AO2 21001", 0, 0, false},
+		{"KSFO 011156Z 28012KT 10SM 15/08 A3002 RMK AO2 1014 21001", 0, 0, false},
+		{"KSFO 011156Z 28012KT 10SM 15/08 A3002 RMK AO2 12142 21001", 0, 0, false},
+		{"KSFO 011256Z 28012KT 10SM 15/08 A3002 RMK AO2 SLP165", 0, 0, false},
+		{"KSFO 011256Z 28012KT 10SM 15/08 A3002", 0, 0, false},
+	}
+	for _, test := range tests {
+		maxC, minC, ok := (&METAR{RawText: test.raw}).SixHourExtremes()
+		if ok != test.ok || maxC != test.maxC || minC != test.minC {
+			t.Errorf("SixHourExtremes(%q) = %v, %v, %t; want %v, %v, %t", test.raw, maxC, minC, ok,
+				test.maxC, test.minC, test.ok)
+		}
+	}
+}
+
+func TestSeaLevelPressure(t *testing.T) {
+	tests := []struct {
+		metar *METAR
+		want  float32
+		ok    bool
+	}{
+		{&METAR{RawText: "KSFO 011256Z 28012KT 10SM 15/08 A2950 RMK AO2 SLP982"}, 998.2, true},
+		{&METAR{RawText: "KSFO 011256Z 28012KT 10SM 15/08 A2992 RMK AO2 SLP013"}, 1001.3, true},
+		{&METAR{RawText: "KSFO 011256Z 28012KT 10SM 15/08 A2657 RMK AO2 SLP500"}, 950.0, true},
+		{&METAR{RawText: "KSFO 011256Z 28012KT 10SM 15/08 A3133 RMK AO2 SLP499"}, 1049.9, true},
+		{&METAR{RawText: "KSFO 011256Z 28012KT 10SM 15/08 A2992 RMK AO2 SLP000"}, 1000.0, true},
+		{&METAR{RawText: "KSFO 011256Z 28012KT 10SM 15/08 A2992 RMK AO2 SLP999"}, 999.9, true},
+		{&METAR{RawText: "KSFO 011256Z 28012KT 10SM 15/08 A2992 RMK AO2 SLPNO"}, 0, false},
+		{&METAR{RawText: "KSFO 011256Z 28012KT 10SM 15/08 A2992 RMK AO2 SLP12"}, 0, false},
+		{&METAR{RawText: "KSFO 011256Z 28012KT 10SM 15/08 A2992 SLP132"}, 0, false},
+		{&METAR{RawText: "KSFO 011256Z 28012KT 10SM 15/08 A2992 RMK AO2 SLP132", SeaLevelPressureMB: 1013.1},
+			1013.1, true},
+	}
+	for _, test := range tests {
+		got, ok := test.metar.SeaLevelPressure()
+		if ok != test.ok || math.Abs(float64(got-test.want)) > 0.01 {
+			t.Errorf("SeaLevelPressure(%q) = %v, %t; want %v, %t", test.metar.RawText, got, ok, test.want, test.ok)
+		}
+	}
+}
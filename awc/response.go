@@ -0,0 +1,369 @@
+package awc
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// WorstFlightCategory returns the most restrictive flight category across all METARs of the response.
+// The precedence is LIFR > IFR > MVFR > VFR; the flight category is computed using ComputeFlightCategory if the server
+// omitted it and METARs whose flight category is still unknown are ignored.
+// An empty string is returned if no METAR has a known flight category, e.g. because the response is empty.
+func (response *METARResponse) WorstFlightCategory() string {
+	worst := ""
+	for _, metar := range response.METARs {
+		if category := metar.flightCategory(); flightCategorySeverity(category) > flightCategorySeverity(worst) {
+			worst = category
+		}
+	}
+	return worst
+}
+
+// filter creates a new METARResponse containing only the METARs keep returns true for.
+// The original response is not modified.
+func (response *METARResponse) filter(keep func(metar *METAR) bool) *METARResponse {
+	filtered := &METARResponse{
+		XMLName:  response.XMLName,
+		Errors:   response.Errors,
+		Warnings: response.Warnings,
+	}
+	for _, metar := range response.METARs {
+		if keep(metar) {
+			filtered.METARs = append(filtered.METARs, metar)
+		}
+	}
+	return filtered
+}
+
+// FilterByElevation creates a new METARResponse containing only the METARs of stations with an elevation between minM
+// and maxM meters (inclusive).
+// As the server omits the elevation of some stations, METARs with an ElevationM of 0 are considered to lack elevation
+// data and are excluded.
+func (response *METARResponse) FilterByElevation(minM, maxM float32) *METARResponse {
+	return response.filter(func(metar *METAR) bool {
+		return metar.ElevationM != 0 && metar.ElevationM >= minM && metar.ElevationM <= maxM
+	})
+}
+
+// Dedupe creates a new METARResponse without duplicate METARs, preserving the order of the remaining ones.
+// METARs are considered duplicates if their StationID, ObservationTime and RawText are identical; the first occurrence
+// is kept. The original response is not modified.
+func (response *METARResponse) Dedupe() *METARResponse {
+	seen := make(map[metarKey]bool)
+	return response.filter(func(metar *METAR) bool {
+		if seen[metar.key()] {
+			return false
+		}
+		seen[metar.key()] = true
+		return true
+	})
+}
+
+// metarKey identifies a METAR when looking for duplicates
+type metarKey struct {
+	stationID, observationTime, rawText string
+}
+
+func (metar *METAR) key() metarKey {
+	return metarKey{metar.StationID, metar.ObservationTime, metar.RawText}
+}
+
+// BackfillFlightCategory sets the FlightCategory of every METAR the server did not provide one for using
+// METAR.ComputeFlightCategory.
+// Please keep in mind that this mutates the METARs of the response in place. METARs whose category can not be
+// determined keep an empty FlightCategory.
+func (response *METARResponse) BackfillFlightCategory() {
+	for _, metar := range response.METARs {
+		if metar.FlightCategory == "" {
+			metar.FlightCategory = metar.ComputeFlightCategory()
+		}
+	}
+}
+
+// isNewerThan reports whether the METAR was observed after other.
+// If any of the observation times can not be parsed, the raw values are compared instead.
+func (metar *METAR) isNewerThan(other *METAR) bool {
+	observedAt, err := metar.ObservedAt()
+	otherObservedAt, otherErr := other.ObservedAt()
+	if err != nil || otherErr != nil {
+		return metar.ObservationTime > other.ObservationTime
+	}
+	return observedAt.After(otherObservedAt)
+}
+
+// LatestPerStation creates a new METARResponse containing only the most recent METAR of every station.
+// The remaining METARs keep their relative order. The original response is not modified.
+func (response *METARResponse) LatestPerStation() *METARResponse {
+	latest := make(map[string]*METAR)
+	for _, metar := range response.METARs {
+		if current, ok := latest[metar.StationID]; !ok || metar.isNewerThan(current) {
+			latest[metar.StationID] = metar
+		}
+	}
+	return response.filter(func(metar *METAR) bool {
+		return latest[metar.StationID] == metar
+	})
+}
+
+// AsMap returns the METARs of the response keyed by their StationID.
+// If a station reported multiple METARs, the most recent one is used; please refer to LatestPerStation.
+func (response *METARResponse) AsMap() map[string]*METAR {
+	latest := response.LatestPerStation()
+
+	metars := make(map[string]*METAR, len(latest.METARs))
+	for _, metar := range latest.METARs {
+		metars[metar.StationID] = metar
+	}
+	return metars
+}
+
+// Aggregate represents the conditions averaged across all METARs of a METARResponse
+type Aggregate struct {
+	// AvgTempC is the mean air temperature of the TempCount METARs reporting one
+	AvgTempC  float32
+	TempCount int
+
+	// AvgWindKT and AvgWindDirDegrees describe the vector-averaged wind of the WindCount METARs reporting a calm or
+	// directional wind; variable and missing winds are excluded.
+	// Opposing winds cancel each other out, so AvgWindKT may be well below the mean wind speed. AvgWindDirDegrees is
+	// the direction the averaged wind blows from and 0 if AvgWindKT is 0.
+	AvgWindKT         float32
+	AvgWindDirDegrees int
+	WindCount         int
+
+	// CategoryCounts maps every reported flight category to the amount of METARs reporting it.
+	// DominantFlightCategory is the most frequent one; ties are resolved in favour of the more restrictive category.
+	CategoryCounts         map[string]int
+	DominantFlightCategory string
+}
+
+// Aggregate computes the average conditions across all METARs of the response.
+// METARs lacking a field are excluded from the respective average; the amount of METARs contributing to each average is
+// reported as well.
+func (response *METARResponse) Aggregate() Aggregate {
+	aggregate := Aggregate{CategoryCounts: make(map[string]int)}
+
+	var tempSum, uSum, vSum float64
+	for _, metar := range response.METARs {
+		if hasTemperature, _ := metar.hasTemperatures(); hasTemperature {
+			tempSum += float64(metar.AirTempC)
+			aggregate.TempCount++
+		}
+
+		if u, v, ok := metar.WindVector(); ok {
+			uSum += float64(u)
+			vSum += float64(v)
+			aggregate.WindCount++
+		} else if metar.IsCalm() {
+			aggregate.WindCount++
+		}
+
+		if flightCategorySeverity(metar.FlightCategory) > 0 {
+			aggregate.CategoryCounts[metar.FlightCategory]++
+		}
+	}
+
+	if aggregate.TempCount > 0 {
+		aggregate.AvgTempC = float32(tempSum / float64(aggregate.TempCount))
+	}
+
+	if aggregate.WindCount > 0 {
+		u := uSum / float64(aggregate.WindCount)
+		v := vSum / float64(aggregate.WindCount)
+		speed := math.Hypot(u, v)
+		// exactly opposing winds leave a rounding error instead of 0
+		if speed < 1e-6 {
+			speed = 0
+		}
+		aggregate.AvgWindKT = float32(speed)
+		if speed > 0 {
+			direction := int(math.Round(math.Atan2(-u, -v)*180/math.Pi)) % 360
+			if direction <= 0 {
+				direction += 360
+			}
+			aggregate.AvgWindDirDegrees = direction
+		}
+	}
+
+	for category, count := range aggregate.CategoryCounts {
+		dominant := aggregate.DominantFlightCategory
+		dominantCount := aggregate.CategoryCounts[dominant]
+		moreRestrictive := flightCategorySeverity(category) > flightCategorySeverity(dominant)
+		if count > dominantCount || (count == dominantCount && moreRestrictive) {
+			aggregate.DominantFlightCategory = category
+		}
+	}
+
+	return aggregate
+}
+
+// TemperatureTrend computes the trend of the air temperature reported by the given station using a simple linear
+// regression of AirTempC over the observation time.
+// The slope is given in degrees Celsius per hour; positive values denote warming. METARs lacking the air temperature or
+// a parseable observation time are ignored; ok is false if fewer than two observations at distinct times remain.
+func (response *METARResponse) TemperatureTrend(stationID string) (slope float32, ok bool) {
+	var hours, temperatures []float64
+	for _, metar := range response.METARs {
+		if !strings.EqualFold(metar.StationID, stationID) {
+			continue
+		}
+		if hasTemperature, _ := metar.hasTemperatures(); !hasTemperature {
+			continue
+		}
+		observedAt, err := metar.ObservedAt()
+		if err != nil {
+			continue
+		}
+		hours = append(hours, float64(observedAt.Unix())/3600)
+		temperatures = append(temperatures, float64(metar.AirTempC))
+	}
+	if len(hours) < 2 {
+		return 0, false
+	}
+
+	var meanHour, meanTemperature float64
+	for i := range hours {
+		meanHour += hours[i]
+		meanTemperature += temperatures[i]
+	}
+	meanHour /= float64(len(hours))
+	meanTemperature /= float64(len(hours))
+
+	var covariance, variance float64
+	for i := range hours {
+		covariance += (hours[i] - meanHour) * (temperatures[i] - meanTemperature)
+		variance += (hours[i] - meanHour) * (hours[i] - meanHour)
+	}
+	if variance == 0 {
+		return 0, false
+	}
+	return float32(covariance / variance), true
+}
+
+// HourlySlots assigns the observations of the given station to the full hours between start and end (inclusive),
+// choosing the observation closest to the top of every hour.
+// Only observations within 30 minutes of an hour are considered, so hours without such an observation map to nil. The
+// keys of the returned map are in UTC. METARs with an observation time that can not be parsed are ignored.
+func (response *METARResponse) HourlySlots(stationID string, start, end time.Time) map[time.Time]*METAR {
+	slots := make(map[time.Time]*METAR)
+	first := start.UTC().Truncate(time.Hour)
+	if first.Before(start) {
+		first = first.Add(time.Hour)
+	}
+	for hour := first; !hour.After(end); hour = hour.Add(time.Hour) {
+		slots[hour] = nil
+	}
+
+	distances := make(map[time.Time]time.Duration)
+	for _, metar := range response.METARs {
+		if !strings.EqualFold(metar.StationID, stationID) {
+			continue
+		}
+		observedAt, err := metar.ObservedAt()
+		if err != nil {
+			continue
+		}
+
+		hour := observedAt.UTC().Round(time.Hour)
+		if _, ok := slots[hour]; !ok {
+			continue
+		}
+		distance := observedAt.Sub(hour)
+		if distance < 0 {
+			distance = -distance
+		}
+		if current, ok := distances[hour]; !ok || distance < current {
+			slots[hour] = metar
+			distances[hour] = distance
+		}
+	}
+	return slots
+}
+
+// WithinRadius creates a new METARResponse containing only the METARs of stations within the given radius in nautical
+// miles around the given coordinates.
+// This is intended to double-check the results of a RadialDistance query, whose radius is given in statute miles;
+// multiply it by 0.868976 to get the radius in nautical miles. The original response is not modified.
+func (response *METARResponse) WithinRadius(lat, lon, radiusNM float32) *METARResponse {
+	return response.filter(func(metar *METAR) bool {
+		return metar.DistanceFrom(lat, lon) <= radiusNM*smPerNM
+	})
+}
+
+// WithinRectangle creates a new METARResponse containing only the METARs of stations within the given rectangle
+// consisting of min/max latitude and longitude (inclusive).
+// This is intended to double-check the results of an InRectangle query. The original response is not modified.
+func (response *METARResponse) WithinRectangle(minLat, minLon, maxLat, maxLon float32) *METARResponse {
+	return response.filter(func(metar *METAR) bool {
+		return metar.Latitude >= minLat && metar.Latitude <= maxLat &&
+			metar.Longitude >= minLon && metar.Longitude <= maxLon
+	})
+}
+
+// NearbyWeights specifies how BestNearbyMETAR weighs the proximity of a station against the completeness of its METAR
+type NearbyWeights struct {
+	// DistanceScaleSM is the distance in statute miles at which the proximity score drops to one half.
+	// This defaults to 10 statute miles.
+	DistanceScaleSM float32
+	// ProximityWeight and CompletenessWeight are the exponents applied to the respective scores; 0 ignores a score.
+	ProximityWeight    float32
+	CompletenessWeight float32
+}
+
+// DefaultNearbyWeights returns the weights used by BestNearbyMETAR if no weights are specified, i.e. a distance scale
+// of 10 statute miles and weights of 1
+func DefaultNearbyWeights() NearbyWeights {
+	return NearbyWeights{
+		DistanceScaleSM:    10,
+		ProximityWeight:    1,
+		CompletenessWeight: 1,
+	}
+}
+
+// BestNearbyMETAR picks the most useful METAR of the response for the given point, i.e. the one maximizing the
+// product of its proximity and completeness scores.
+// The proximity score is 1 / (1 + d / DistanceScaleSM) for a station d statute miles away (see DistanceFrom) and the
+// completeness score is returned by Completeness; both range from 0 to 1 and are raised to the power of their
+// respective weight. Thus, a distant station with complete data may be preferred over a close one with sensors off.
+// DefaultNearbyWeights is used if weights is nil. nil is returned if the response contains no METARs; ties are
+// resolved in favour of the closer station.
+func BestNearbyMETAR(response *METARResponse, lat, lon float32, weights *NearbyWeights) *METAR {
+	defaults := DefaultNearbyWeights()
+	if weights == nil {
+		weights = &defaults
+	}
+	scale := float64(weights.DistanceScaleSM)
+	if scale <= 0 {
+		scale = float64(defaults.DistanceScaleSM)
+	}
+
+	var best *METAR
+	bestScore, bestDistance := -1.0, 0.0
+	for _, metar := range response.METARs {
+		distance := float64(metar.DistanceFrom(lat, lon))
+		proximity := 1 / (1 + distance/scale)
+		score := math.Pow(proximity, float64(weights.ProximityWeight)) *
+			math.Pow(float64(metar.Completeness()), float64(weights.CompletenessWeight))
+		if score > bestScore || (score == bestScore && distance < bestDistance) {
+			best, bestScore, bestDistance = metar, score, distance
+		}
+	}
+	return best
+}
+
+// GroupByFlightCategory groups the METARs of the response by their flight category.
+// The keys are the FlightCategory constants (VFR, MVFR, IFR and LIFR) and FlightCategoryUnknown for METARs whose flight
+// category can neither be taken from the server nor be computed using ComputeFlightCategory. Only keys with at least
+// one METAR are present; the METARs of every key keep the order of the response.
+func (response *METARResponse) GroupByFlightCategory() map[string][]*METAR {
+	groups := make(map[string][]*METAR)
+	for _, metar := range response.METARs {
+		category := metar.flightCategory()
+		if flightCategorySeverity(category) == 0 {
+			category = FlightCategoryUnknown
+		}
+		groups[category] = append(groups[category], metar)
+	}
+	return groups
+}
@@ -0,0 +1,77 @@
+package awc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAggregateWind(t *testing.T) {
+	tests := []struct {
+		name      string
+		raws      []string
+		speedKT   float32
+		dirDeg    int
+		windCount int
+	}{
+		{"opposing", []string{
+			"KSFO 011256Z 09010KT 10SM FEW020 15/08 A3002",
+			"KOAK 011253Z 27010KT 10SM FEW020 15/08 A3002",
+		}, 0, 0, 2},
+		{"across north", []string{
+			"KSFO 011256Z 35010KT 10SM FEW020 15/08 A3002",
+			"KOAK 011253Z 01010KT 10SM FEW020 15/08 A3002",
+		}, 9.848, 360, 2},
+		{"with calm", []string{
+			"KSFO 011256Z 18010KT 10SM FEW020 15/08 A3002",
+			"KOAK 011253Z 00000KT 10SM FEW020 15/08 A3002",
+		}, 5, 180, 2},
+		{"VRB and missing winds excluded", []string{
+			"KSFO 011256Z 27012KT 10SM FEW020 15/08 A3002",
+			"KOAK 011253Z VRB03KT 10SM FEW020 15/08 A3002",
+			"KSJC 011253Z 27005G15KT 240V300 10SM FEW020 15/08 A3002",
+			"KHWD 011253Z 10SM FEW020 15/08 A3002",
+		}, 8.5, 270, 2},
+		{"calm only", []string{"KSFO 011256Z 00000KT 10SM FEW020 15/08 A3002"}, 0, 0, 1},
+		{"none", []string{"KOAK 011253Z VRB03KT 10SM FEW020 15/08 A3002"}, 0, 0, 0},
+	}
+	for _, test := range tests {
+		response := new(METARResponse)
+		for _, raw := range test.raws {
+			response.METARs = append(response.METARs, mustParseMETAR(t, raw))
+		}
+		aggregate := response.Aggregate()
+		if math.Abs(float64(aggregate.AvgWindKT-test.speedKT)) > 0.01 || aggregate.AvgWindDirDegrees != test.dirDeg ||
+			aggregate.WindCount != test.windCount {
+			t.Errorf("%s: got %v kt from %d from %d METARs; want %v kt from %d from %d METARs", test.name,
+				aggregate.AvgWindKT, aggregate.AvgWindDirDegrees, aggregate.WindCount, test.speedKT, test.dirDeg,
+				test.windCount)
+		}
+	}
+}
+
+func TestBestNearbyMETAR(t *testing.T) {
+	near := mustParseMETAR(t, "KSFO 011256Z 28012KT 10SM FEW020 15/08 A3002")
+	near.Latitude, near.Longitude = 37.62, -122.37
+	far := mustParseMETAR(t, "KOAK 011253Z 27008KT 10SM FEW020 14/09 A3001")
+	far.Latitude, far.Longitude = 37.65, -122.35
+	response := &METARResponse{METARs: []*METAR{far, near}}
+
+	if best := BestNearbyMETAR(response, 37.62, -122.37, nil); best != near {
+		t.Errorf("expected the closer station to win with complete data, got %s", best.StationID)
+	}
+	near.RawText = "KSFO 011256Z 10SM FEW020 A3002"
+	if best := BestNearbyMETAR(response, 37.62, -122.37, nil); best != far {
+		t.Errorf("expected the far station with complete data to win, got %s", best.StationID)
+	}
+	weights := DefaultNearbyWeights()
+	weights.CompletenessWeight = 0
+	if best := BestNearbyMETAR(response, 37.62, -122.37, &weights); best != near {
+		t.Errorf("expected the closer station to win without a completeness weight, got %s", best.StationID)
+	}
+	if DefaultNearbyWeights().CompletenessWeight != 1 {
+		t.Error("modifying the returned weights affected the defaults")
+	}
+	if BestNearbyMETAR(new(METARResponse), 0, 0, nil) != nil {
+		t.Error("expected nil for an empty response")
+	}
+}
@@ -0,0 +1,178 @@
+package awc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultInitialBackoff = 1 * time.Second
+	defaultMaxBackoff     = 30 * time.Second
+)
+
+// RetryConfig specifies how failed requests are retried.
+// A request is retried if it failed on the network level or the server responded with 429 Too Many Requests or one of
+// the status codes 500, 502, 503 and 504. As the client only sends GET requests, retrying is always safe.
+type RetryConfig struct {
+	// MaxAttempts is the maximum amount of attempts per request, including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+
+	// InitialBackoff is the time to wait before the first retry; it doubles with every further retry up to MaxBackoff.
+	// These default to 1 and 30 seconds respectively.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// MaxRetryAfter caps the time to wait if a 429 or 503 response carries a Retry-After header, which takes precedence
+	// over the backoff schedule.
+	// This defaults to MaxBackoff.
+	MaxRetryAfter time.Duration
+
+	// AttemptTimeout bounds the time of every single attempt, including reading the response body, while the context
+	// of the request keeps bounding the total time across all attempts.
+	// Attempts running into this timeout before a response was received are retried; timeouts while reading the body
+	// are returned as errors. This defaults to 0, meaning that attempts are only bounded by the context.
+	AttemptTimeout time.Duration
+}
+
+// Retry specifies whether and how to retry failed requests.
+// This defaults to nil, meaning that requests are not retried. Waiting for a retry is aborted as soon as the context of
+// the request is done.
+func (client *Client) Retry(config *RetryConfig) *Client {
+	client.retry = config
+	return client
+}
+
+// attempt sends the request once, bounded by the AttemptTimeout of the RetryConfig of the client
+func (client *Client) attempt(request *http.Request) (*http.Response, error) {
+	if client.retry == nil || client.retry.AttemptTimeout <= 0 {
+		return client.getHTTPClient().Do(request)
+	}
+
+	ctx, cancel := context.WithTimeout(request.Context(), client.retry.AttemptTimeout)
+	response, err := client.getHTTPClient().Do(request.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	response.Body = &cancelingBody{ReadCloser: response.Body, cancel: cancel}
+	return response, nil
+}
+
+// cancelingBody cancels the context of an attempt as soon as the response body is closed
+type cancelingBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (body *cancelingBody) Close() error {
+	err := body.ReadCloser.Close()
+	body.cancel()
+	return err
+}
+
+// backoff returns the time to wait before the given retry (starting at 1) according to the backoff schedule
+func (config *RetryConfig) backoff(retry int) time.Duration {
+	wait := config.InitialBackoff
+	if wait <= 0 {
+		wait = defaultInitialBackoff
+	}
+	maxWait := config.getMaxBackoff()
+	for i := 1; i < retry && wait < maxWait; i++ {
+		wait *= 2
+	}
+	if wait > maxWait {
+		return maxWait
+	}
+	return wait
+}
+
+func (config *RetryConfig) getMaxBackoff() time.Duration {
+	if config.MaxBackoff > 0 {
+		return config.MaxBackoff
+	}
+	return defaultMaxBackoff
+}
+
+func (config *RetryConfig) getMaxRetryAfter() time.Duration {
+	if config.MaxRetryAfter > 0 {
+		return config.MaxRetryAfter
+	}
+	return config.getMaxBackoff()
+}
+
+// isRetryable reports whether a request resulting in the given response or error should be retried
+func isRetryable(ctx context.Context, response *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil
+	}
+	switch response.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter parses the Retry-After header of a response, which is either an amount of seconds or an HTTP date.
+// ok is false if the header is absent or malformed. Dates in the past result in a duration of 0.
+func parseRetryAfter(response *http.Response, now time.Time) (wait time.Duration, ok bool) {
+	value := strings.TrimSpace(response.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if wait = date.Sub(now); wait < 0 {
+		wait = 0
+	}
+	return wait, true
+}
+
+// retryWait returns the time to wait before the given retry (starting at 1) of a request that resulted in response
+func (client *Client) retryWait(retry int, response *http.Response) time.Duration {
+	if response != nil &&
+		(response.StatusCode == http.StatusTooManyRequests || response.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := parseRetryAfter(response, client.getNow()); ok {
+			if maxWait := client.retry.getMaxRetryAfter(); wait > maxWait {
+				return maxWait
+			}
+			return wait
+		}
+	}
+	return client.retry.backoff(retry)
+}
+
+// discard consumes and closes the body of a response that is going to be retried, passing it to the OnResponse hook
+func (client *Client) discard(response *http.Response) {
+	body, _ := io.ReadAll(response.Body)
+	response.Body.Close()
+	if client.onResponse != nil {
+		client.onResponse(response, body)
+	}
+}
+
+// sleep waits for the given duration, returning early with the error of ctx if it is done before
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
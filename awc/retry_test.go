@@ -0,0 +1,182 @@
+package awc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		wait  time.Duration
+		ok    bool
+	}{
+		{"120", 2 * time.Minute, true},
+		{" 5 ", 5 * time.Second, true},
+		{"0", 0, true},
+		{"Wed, 01 May 2024 12:00:30 GMT", 30 * time.Second, true},
+		{"Wed, 01 May 2024 11:59:00 GMT", 0, true},
+		{"-5", 0, false},
+		{"soon", 0, false},
+		{"", 0, false},
+	}
+	for _, test := range tests {
+		response := &http.Response{Header: http.Header{"Retry-After": {test.value}}}
+		if wait, ok := parseRetryAfter(response, now); wait != test.wait || ok != test.ok {
+			t.Errorf("parseRetryAfter(%q) = %s, %t; want %s, %t", test.value, wait, ok, test.wait, test.ok)
+		}
+	}
+}
+
+// newFlakyServer starts a server answering the first failures requests with status and the given Retry-After header
+// and all further ones with testMETARResponse
+func newFlakyServer(t *testing.T, failures int32, status int, retryAfter string) (*Client, *int32) {
+	var requests int32
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+			return
+		}
+		serveMETARs(w, r)
+	})
+	return client, &requests
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusServiceUnavailable} {
+		client, requests := newFlakyServer(t, 1, status, "1")
+		client.Retry(&RetryConfig{MaxAttempts: 3, InitialBackoff: 10 * time.Millisecond})
+
+		start := time.Now()
+		if _, err := client.GetMETAR(NewMETARQuery().HoursBeforeNow(1)); err != nil {
+			t.Fatalf("%d: unexpected error: %v", status, err)
+		}
+		if elapsed := time.Since(start); elapsed < time.Second {
+			t.Errorf("%d: expected to wait for the Retry-After duration of 1s, waited %s", status, elapsed)
+		}
+		if atomic.LoadInt32(requests) != 2 {
+			t.Errorf("%d: expected 2 requests, got %d", status, atomic.LoadInt32(requests))
+		}
+	}
+}
+
+func TestRetryAfterIsCapped(t *testing.T) {
+	client, requests := newFlakyServer(t, 1, http.StatusTooManyRequests, "3600")
+	client.Retry(&RetryConfig{MaxAttempts: 2, MaxRetryAfter: 20 * time.Millisecond})
+
+	start := time.Now()
+	if _, err := client.GetMETAR(NewMETARQuery().HoursBeforeNow(1)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected the wait to be capped at MaxRetryAfter, waited %s", elapsed)
+	}
+	if atomic.LoadInt32(requests) != 2 {
+		t.Errorf("expected 2 requests, got %d", atomic.LoadInt32(requests))
+	}
+}
+
+func TestRetryUsesBackoffWithoutRetryAfter(t *testing.T) {
+	client, requests := newFlakyServer(t, 2, http.StatusBadGateway, "1")
+	client.Retry(&RetryConfig{MaxAttempts: 3, InitialBackoff: 10 * time.Millisecond})
+
+	start := time.Now()
+	if _, err := client.GetMETAR(NewMETARQuery().HoursBeforeNow(1)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected Retry-After to be ignored for 502 responses, waited %s", elapsed)
+	}
+	if atomic.LoadInt32(requests) != 3 {
+		t.Errorf("expected 3 requests, got %d", atomic.LoadInt32(requests))
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	client, requests := newFlakyServer(t, 5, http.StatusServiceUnavailable, "")
+	client.Retry(&RetryConfig{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+
+	if _, err := client.GetMETAR(NewMETARQuery().HoursBeforeNow(1)); err == nil {
+		t.Error("expected an error")
+	}
+	if atomic.LoadInt32(requests) != 2 {
+		t.Errorf("expected 2 requests, got %d", atomic.LoadInt32(requests))
+	}
+}
+
+// newSlowServer starts a server answering the first slow requests only after a second or once they are canceled and
+// all further ones immediately with testMETARResponse
+func newSlowServer(t *testing.T, slow int32) (*Client, *int32) {
+	var requests int32
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= slow {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(time.Second):
+			}
+		}
+		serveMETARs(w, r)
+	})
+	return client, &requests
+}
+
+func TestAttemptTimeoutRetriesSlowAttempts(t *testing.T) {
+	client, requests := newSlowServer(t, 1)
+	client.Retry(&RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, AttemptTimeout: 50 * time.Millisecond})
+
+	start := time.Now()
+	response, err := client.GetMETAR(NewMETARQuery().HoursBeforeNow(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected the slow attempt to be abandoned after AttemptTimeout, waited %s", elapsed)
+	}
+	if len(response.METARs) != 1 {
+		t.Errorf("expected 1 METAR, got %d", len(response.METARs))
+	}
+	if atomic.LoadInt32(requests) != 2 {
+		t.Errorf("expected 2 requests, got %d", atomic.LoadInt32(requests))
+	}
+}
+
+func TestAttemptTimeoutIsBoundedByContext(t *testing.T) {
+	client, _ := newSlowServer(t, 1000)
+	client.Retry(&RetryConfig{
+		MaxAttempts:    1000,
+		InitialBackoff: time.Millisecond,
+		AttemptTimeout: 50 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.GetMETARContext(ctx, NewMETARQuery().HoursBeforeNow(1))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the context to bound the total time, waited %s", elapsed)
+	}
+}
+
+func TestWithoutAttemptTimeout(t *testing.T) {
+	client, requests := newSlowServer(t, 1)
+	client.Retry(&RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	if _, err := client.GetMETAR(NewMETARQuery().HoursBeforeNow(1)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if atomic.LoadInt32(requests) != 1 {
+		t.Errorf("expected the slow attempt to be awaited, got %d requests", atomic.LoadInt32(requests))
+	}
+}
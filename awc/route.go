@@ -0,0 +1,67 @@
+package awc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RouteBriefing represents the latest conditions along a route as returned by GetRouteBriefing
+type RouteBriefing struct {
+	// FlightCategory is the most restrictive flight category along the route; it is empty if no METAR has a known
+	// flight category
+	FlightCategory string
+	// StationID is the ID of the station limiting the route, i.e. the one reporting FlightCategory
+	StationID string
+	// METAR is the METAR of the limiting station
+	METAR *METAR
+	// METARs contains the latest METAR of every station in route order; stations without a METAR are skipped
+	METARs []*METAR
+	// Missing contains the stations that did not report any METAR within the last 3 hours
+	Missing []string
+}
+
+// GetRouteBriefing fetches the most recent METAR reported within the last 3 hours for every station of a route and
+// determines the station limiting it.
+// The flight category of the route is determined using METARResponse.WorstFlightCategory. Ties are resolved in route
+// order, meaning the first station reporting the most restrictive flight category is the limiting one.
+// Just like GetMETARs, an error is returned if the AWC Text Data Server reported any errors. An error wrapping
+// ErrNoData is returned if none of the stations reported a METAR in that time.
+func (client *Client) GetRouteBriefing(stations ...string) (*RouteBriefing, error) {
+	if len(stations) == 0 {
+		return nil, errors.New("a route requires at least one station")
+	}
+
+	metars, err := client.GetMETARs(NewMETARQuery().
+		Stations(stations...).
+		HoursBeforeNow(3).
+		MostRecentForEachStation("constraint"))
+	if err != nil && !errors.Is(err, ErrNoData) {
+		return nil, err
+	}
+	latest := (&METARResponse{METARs: metars}).AsMap()
+
+	briefing := new(RouteBriefing)
+	for _, station := range stations {
+		metar, ok := latest[strings.ToUpper(station)]
+		if !ok {
+			briefing.Missing = append(briefing.Missing, station)
+			continue
+		}
+		briefing.METARs = append(briefing.METARs, metar)
+	}
+
+	briefing.FlightCategory = (&METARResponse{METARs: briefing.METARs}).WorstFlightCategory()
+	for _, metar := range briefing.METARs {
+		if briefing.FlightCategory != "" && metar.flightCategory() == briefing.FlightCategory {
+			briefing.StationID = metar.StationID
+			briefing.METAR = metar
+			break
+		}
+	}
+
+	if len(briefing.METARs) == 0 {
+		return nil, fmt.Errorf("%w: no METAR reported along the route within the last 3 hours", ErrNoData)
+	}
+	return briefing, nil
+}
@@ -0,0 +1,98 @@
+package awc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const testRouteResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<response>
+  <data num_results="3">
+    <METAR>
+      <raw_text>KSFO 011256Z 28012KT 10SM FEW020 15/08 A3002</raw_text>
+      <station_id>KSFO</station_id>
+      <observation_time>2024-05-01T12:56:00Z</observation_time>
+      <flight_category>VFR</flight_category>
+    </METAR>
+    <METAR>
+      <raw_text>KOAK 011253Z 27008KT 2SM BR OVC007 13/12 A3001</raw_text>
+      <station_id>KOAK</station_id>
+      <observation_time>2024-05-01T12:53:00Z</observation_time>
+      <visibility_statute_mi>2</visibility_statute_mi>
+      <sky_condition sky_cover="OVC" cloud_base_ft_agl="700" />
+    </METAR>
+    <METAR>
+      <raw_text>KSJC 011253Z 31006KT 2SM BR OVC008 14/12 A3001</raw_text>
+      <station_id>KSJC</station_id>
+      <observation_time>2024-05-01T12:53:00Z</observation_time>
+      <flight_category>IFR</flight_category>
+    </METAR>
+  </data>
+</response>`
+
+func TestGetRouteBriefing(t *testing.T) {
+	_, client := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		fmt.Fprint(w, testRouteResponse)
+	})
+
+	briefing, err := client.GetRouteBriefing("ksfo", "KLAX", "KSJC", "KOAK")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if briefing.FlightCategory != FlightCategoryIFR || briefing.StationID != "KSJC" || briefing.METAR == nil {
+		t.Errorf("got limiting station %q with %q; want KSJC with IFR", briefing.StationID, briefing.FlightCategory)
+	}
+	var order []string
+	for _, metar := range briefing.METARs {
+		order = append(order, metar.StationID)
+	}
+	if strings.Join(order, ",") != "KSFO,KSJC,KOAK" {
+		t.Errorf("got METARs of %v; want them in route order", order)
+	}
+	if len(briefing.Missing) != 1 || briefing.Missing[0] != "KLAX" {
+		t.Errorf("got missing stations %v; want [KLAX]", briefing.Missing)
+	}
+
+	briefing, err = client.GetRouteBriefing("KSFO", "KOAK")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if briefing.FlightCategory != FlightCategoryIFR || briefing.StationID != "KOAK" {
+		t.Errorf("got limiting station %q with %q; want the computed IFR of KOAK", briefing.StationID,
+			briefing.FlightCategory)
+	}
+}
+
+func TestGetRouteBriefingErrors(t *testing.T) {
+	tests := []struct {
+		name, body string
+		want       func(err error) bool
+	}{
+		{"api error", `<response><errors><error>Query timed out</error></errors><data num_results="0" /></response>`,
+			func(err error) bool { return err != nil && strings.Contains(err.Error(), "Query timed out") }},
+		{"no data", `<response><data num_results="0" /></response>`,
+			func(err error) bool { return errors.Is(err, ErrNoData) }},
+	}
+	for _, test := range tests {
+		body := test.body
+		_, client := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "text/xml")
+			fmt.Fprint(w, body)
+		})
+		briefing, err := client.GetRouteBriefing("KSFO", "KOAK")
+		if !test.want(err) {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+		if briefing != nil {
+			t.Errorf("%s: expected no briefing, got %+v", test.name, briefing)
+		}
+	}
+
+	if _, err := new(Client).GetRouteBriefing(); err == nil {
+		t.Error("expected an error for an empty route")
+	}
+}
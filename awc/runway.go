@@ -0,0 +1,144 @@
+package awc
+
+import (
+	"strconv"
+	"strings"
+)
+
+var runwayDeposits = map[byte]string{
+	'0': "clear and dry",
+	'1': "damp",
+	'2': "wet or water patches",
+	'3': "rime or frost",
+	'4': "dry snow",
+	'5': "wet snow",
+	'6': "slush",
+	'7': "ice",
+	'8': "compacted or rolled snow",
+	'9': "frozen ruts or ridges",
+}
+
+var runwayContaminations = map[byte]string{
+	'1': "10% or less",
+	'2': "11% to 25%",
+	'5': "26% to 50%",
+	'9': "51% to 100%",
+}
+
+var runwayBrakingActions = map[string]string{
+	"91": "poor",
+	"92": "medium/poor",
+	"93": "medium",
+	"94": "medium/good",
+	"95": "good",
+	"99": "unreliable",
+}
+
+// RunwayCondition represents a single runway state group of a METAR
+type RunwayCondition struct {
+	// Runway is the runway designator, e.g. "24L"; "88" denotes all runways
+	Runway string
+	// Cleared indicates that the contamination of the runway has ceased to exist ('CLRD')
+	Cleared bool
+	// Deposit describes the runway deposit, e.g. "wet snow"; it is empty if not reported
+	Deposit string
+	// Contamination describes the extent of the contamination, e.g. "51% to 100%"; it is empty if not reported
+	Contamination string
+	// DepthMM is the depth of the deposit in millimeters; it is -1 if not reported, not measurable or the runway is not
+	// operational
+	DepthMM int
+	// Friction is the friction coefficient (e.g. 0.45); it is 0 if not reported or a braking action is reported instead
+	Friction float32
+	// BrakingAction is the estimated braking action, e.g. "medium/good"; it is empty if not reported or a friction
+	// coefficient is reported instead
+	BrakingAction string
+}
+
+// RunwayConditions parses the runway state groups of the raw METAR text.
+// The WMO/ICAO conventions used in Europe are supported: the current notation with runway designator
+// (e.g. 'R24L/290195' or 'R24L/CLRD70') and the former 8-digit notation (e.g. '88290195'), in which runways 51 to 86
+// denote the right-hand runways 01 to 36. Runway conditions are not reported this way in US METARs.
+func (metar *METAR) RunwayConditions() []RunwayCondition {
+	var conditions []RunwayCondition
+	for _, group := range metar.body() {
+		if condition, ok := parseRunwayCondition(group); ok {
+			conditions = append(conditions, condition)
+		}
+	}
+	return conditions
+}
+
+func parseRunwayCondition(group string) (RunwayCondition, bool) {
+	var runway, state string
+	if strings.HasPrefix(group, "R") && strings.Contains(group, "/") {
+		parts := strings.SplitN(group[1:], "/", 2)
+		runway, state = parts[0], parts[1]
+		if len(runway) < 2 || !isDigits(runway[:2]) || len(strings.TrimRight(runway[2:], "LCR")) != 0 {
+			return RunwayCondition{}, false
+		}
+	} else if len(group) == 8 && isDigits(group[:2]) {
+		runway, state = group[:2], group[2:]
+		if number, _ := strconv.Atoi(runway); number > 50 && number <= 86 {
+			runway = strconv.Itoa(number-50) + "R"
+			if len(runway) == 2 {
+				runway = "0" + runway
+			}
+		}
+	} else {
+		return RunwayCondition{}, false
+	}
+
+	condition := RunwayCondition{Runway: runway, DepthMM: -1}
+
+	braking := ""
+	if strings.HasPrefix(state, "CLRD") && len(state) == 6 {
+		condition.Cleared = true
+		braking = state[4:]
+	} else if len(state) == 6 && isRunwayStateCode(state) {
+		condition.Deposit = runwayDeposits[state[0]]
+		condition.Contamination = runwayContaminations[state[1]]
+		if depth := state[2:4]; isDigits(depth) {
+			condition.DepthMM = decodeRunwayDepth(depth)
+		}
+		braking = state[4:]
+	} else {
+		return RunwayCondition{}, false
+	}
+
+	if !isDigits(braking) {
+		if braking != "//" {
+			return RunwayCondition{}, false
+		}
+		return condition, true
+	}
+	if action, ok := runwayBrakingActions[braking]; ok {
+		condition.BrakingAction = action
+	} else {
+		coefficient, _ := strconv.Atoi(braking)
+		condition.Friction = float32(coefficient) / 100
+	}
+	return condition, true
+}
+
+// isRunwayStateCode reports whether value consists of digits and '/' placeholders only
+func isRunwayStateCode(value string) bool {
+	for _, char := range value {
+		if (char < '0' || char > '9') && char != '/' {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeRunwayDepth decodes the two-digit deposit depth code to millimeters
+func decodeRunwayDepth(code string) int {
+	value, _ := strconv.Atoi(code)
+	switch {
+	case value <= 90:
+		return value
+	case value >= 92 && value <= 98:
+		return (value - 90) * 50
+	default:
+		return -1
+	}
+}
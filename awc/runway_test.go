@@ -0,0 +1,43 @@
+package awc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRunwayConditions(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want []RunwayCondition
+	}{
+		{
+			"EFHK 011250Z 33008KT 9999 -SN BKN015 M05/M08 Q1011 R04R/590242 R15/490195",
+			[]RunwayCondition{
+				{Runway: "04R", Deposit: "wet snow", Contamination: "51% to 100%", DepthMM: 2, Friction: 0.42},
+				{Runway: "15", Deposit: "dry snow", Contamination: "51% to 100%", DepthMM: 1, BrakingAction: "good"},
+			},
+		},
+		{
+			"ESSA 011250Z 01005KT 9999 BKN012 M02/M04 Q1003 R19R/7192// R26/CLRD70",
+			[]RunwayCondition{
+				{Runway: "19R", Deposit: "ice", Contamination: "10% or less", DepthMM: 100},
+				{Runway: "26", Cleared: true, DepthMM: -1, Friction: 0.7},
+			},
+		},
+		{
+			"UUEE 011230Z 27005MPS 9999 SCT030 M10/M15 Q1020 88829860 56//2192",
+			[]RunwayCondition{
+				{Runway: "88", Deposit: "compacted or rolled snow", Contamination: "11% to 25%", DepthMM: 400,
+					Friction: 0.6},
+				{Runway: "06R", DepthMM: 21, BrakingAction: "medium/poor"},
+			},
+		},
+		{"EDDF 011250Z 28012KT 0400 R25L/0600U FG VV001 08/08 Q1012", nil},
+		{"KSFO 011256Z 28012KT 10SM FEW020 15/08 A3002 RMK AO2", nil},
+	}
+	for _, test := range tests {
+		if got := (&METAR{RawText: test.raw}).RunwayConditions(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("RunwayConditions(%q) =\n%+v; want\n%+v", test.raw, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package awc
+
+import "encoding/xml"
+
+// schemaElement represents an arbitrary XML element including all of its child elements
+type schemaElement struct {
+	XMLName  xml.Name        `xml:""`
+	Children []schemaElement `xml:",any"`
+}
+
+// knownResponseElements contains the names of all known child elements of the <response> element
+var knownResponseElements = []string{
+	"request_index",
+	"data_source",
+	"request",
+	"errors",
+	"warnings",
+	"time_taken_ms",
+	"data",
+}
+
+// findUnknownElements decodes the <response> element of a METAR response body and returns the paths of all child
+// elements of the response itself and of its METARs that are not known to this package.
+func findUnknownElements(body []byte) ([]string, error) {
+	root := new(schemaElement)
+	if err := decodeResponse(body, root); err != nil {
+		return nil, err
+	}
+
+	var unknown []string
+	for _, child := range root.Children {
+		if !containsString(knownResponseElements, child.XMLName.Local) {
+			unknown = appendUnique(unknown, child.XMLName.Local)
+			continue
+		}
+		if child.XMLName.Local != "data" {
+			continue
+		}
+
+		for _, metar := range child.Children {
+			if metar.XMLName.Local != "METAR" {
+				unknown = appendUnique(unknown, "data>"+metar.XMLName.Local)
+				continue
+			}
+			for _, field := range metar.Children {
+				if !isMETARField(field.XMLName.Local) {
+					unknown = appendUnique(unknown, "data>METAR>"+field.XMLName.Local)
+				}
+			}
+		}
+	}
+	return unknown, nil
+}
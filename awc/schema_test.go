@@ -0,0 +1,76 @@
+package awc
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCheckSchema(t *testing.T) {
+	const body = `<?xml version="1.0" encoding="UTF-8"?>
+<response>
+  <request_index>1</request_index>
+  <data_source name="metars" />
+  <request type="retrieve" />
+  <errors />
+  <warnings><warning>partial data</warning></warnings>
+  <time_taken_ms>12</time_taken_ms>
+  <quota remaining="99" />
+  <data num_results="2">
+    <METAR>
+      <raw_text>KSFO 011256Z 28012KT 10SM FEW020 15/08 A3002</raw_text>
+      <station_id>KSFO</station_id>
+      <observation_time>2024-05-01T12:56:00Z</observation_time>
+      <sky_condition sky_cover="FEW" cloud_base_ft_agl="2000" />
+      <wind_shear>none</wind_shear>
+    </METAR>
+    <METAR>
+      <raw_text>KOAK 011253Z 27008KT 10SM SKC 14/09 A3001</raw_text>
+      <station_id>KOAK</station_id>
+      <wind_shear>none</wind_shear>
+    </METAR>
+    <TAF />
+  </data>
+</response>`
+	_, client := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		fmt.Fprint(w, body)
+	})
+	query := NewMETARQuery().HoursBeforeNow(1)
+
+	response, err := client.GetMETAR(query)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(response.Warnings, []string{"partial data"}) {
+		t.Errorf("expected the schema not to be checked by default, got warnings %q", response.Warnings)
+	}
+
+	response, err = client.CheckSchema(true).GetMETAR(query)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"partial data", "unknown element(s): quota, data>METAR>wind_shear, data>TAF"}
+	if !reflect.DeepEqual(response.Warnings, want) {
+		t.Errorf("got warnings %q; want %q", response.Warnings, want)
+	}
+	if len(response.METARs) != 2 {
+		t.Errorf("expected the METARs to be decoded regardless, got %d", len(response.METARs))
+	}
+
+	if _, err := client.WarningsAsErrors(true).GetMETAR(query); err == nil {
+		t.Error("expected the schema warning to be returned as an error")
+	}
+}
+
+func TestCheckSchemaKnownElements(t *testing.T) {
+	_, client := newTestServer(t, serveMETARs)
+	response, err := client.CheckSchema(true).WarningsAsErrors(true).GetMETAR(NewMETARQuery().HoursBeforeNow(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(response.Warnings) != 0 {
+		t.Errorf("unexpected warnings: %q", response.Warnings)
+	}
+}
@@ -0,0 +1,117 @@
+package awc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SkyCover represents the amount of sky covered by a cloud layer
+type SkyCover int
+
+// The sky covers reported by the AWC Text Data Server
+const (
+	SkyCoverUnknown SkyCover = iota
+	SkyCoverSKC
+	SkyCoverCLR
+	SkyCoverCAVOK
+	SkyCoverNSC
+	SkyCoverFEW
+	SkyCoverSCT
+	SkyCoverBKN
+	SkyCoverOVC
+	SkyCoverOVX
+)
+
+var skyCoverNames = map[SkyCover]string{
+	SkyCoverSKC:   "SKC",
+	SkyCoverCLR:   "CLR",
+	SkyCoverCAVOK: "CAVOK",
+	SkyCoverNSC:   "NSC",
+	SkyCoverFEW:   "FEW",
+	SkyCoverSCT:   "SCT",
+	SkyCoverBKN:   "BKN",
+	SkyCoverOVC:   "OVC",
+	SkyCoverOVX:   "OVX",
+}
+
+// ParseSkyCover parses a sky cover abbreviation like "BKN".
+// SkyCoverUnknown is returned for unknown abbreviations.
+func ParseSkyCover(value string) SkyCover {
+	for cover, name := range skyCoverNames {
+		if name == value {
+			return cover
+		}
+	}
+	return SkyCoverUnknown
+}
+
+func (cover SkyCover) String() string {
+	if name, ok := skyCoverNames[cover]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+// isCeiling reports whether a layer of this sky cover constitutes a ceiling
+func (cover SkyCover) isCeiling() bool {
+	return cover == SkyCoverBKN || cover == SkyCoverOVC || cover == SkyCoverOVX
+}
+
+// CloudLayer represents a single classified sky condition of a METAR
+type CloudLayer struct {
+	Cover SkyCover
+	// BaseFT is the cloud base in feet above ground level; for obscured skies (OVX) this is the vertical visibility
+	BaseFT int
+	// IsCeiling is true for the lowest BKN, OVC or OVX layer
+	IsCeiling bool
+	// CloudType is either "CB" (cumulonimbus) or "TCU" (towering cumulus) if reported for the layer in the raw text
+	CloudType string
+}
+
+// CloudLayers classifies the sky conditions of the METAR in the order they were reported.
+// Cloud types are taken from the matching sky condition group of the raw text, e.g. 'BKN030CB'.
+func (metar *METAR) CloudLayers() []CloudLayer {
+	groups := metar.body()
+
+	layers := make([]CloudLayer, 0, len(metar.SkyConditions))
+	ceiling := -1
+	for _, condition := range metar.SkyConditions {
+		layer := CloudLayer{
+			Cover:  ParseSkyCover(condition.SkyCover),
+			BaseFT: condition.CloudBaseFTAGL,
+		}
+		if layer.Cover == SkyCoverOVX && layer.BaseFT == 0 {
+			layer.BaseFT = metar.VerticalVisibilityFT
+		}
+
+		prefix := fmt.Sprintf("%s%03d", condition.SkyCover, condition.CloudBaseFTAGL/100)
+		for _, group := range groups {
+			if cloudType := strings.TrimPrefix(group, prefix); cloudType == "CB" || cloudType == "TCU" {
+				layer.CloudType = cloudType
+				break
+			}
+		}
+
+		if layer.Cover.isCeiling() && (ceiling < 0 || layer.BaseFT < layers[ceiling].BaseFT) {
+			ceiling = len(layers)
+		}
+		layers = append(layers, layer)
+	}
+
+	if ceiling >= 0 {
+		layers[ceiling].IsCeiling = true
+	}
+	return layers
+}
+
+// Ceiling returns the height of the ceiling in feet above ground level, i.e. the base of the lowest BKN, OVC or OVX
+// layer.
+// ok is false if there is no ceiling.
+func (metar *METAR) Ceiling() (int, bool) {
+	for _, layer := range metar.CloudLayers() {
+		if layer.IsCeiling {
+			return layer.BaseFT, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,233 @@
+package awc
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ICAOFromIATA translates a 3-letter IATA airport code to the 4-letter ICAO code.
+// The given mapping is consulted first; if it does not contain the code, the code is prefixed with 'K'.
+// Please keep in mind that this heuristic only holds for the contiguous US: airports in Alaska ('PA'), Hawaii ('PH')
+// and any other country have to be covered by the mapping. Values that are no 3-letter codes are returned unchanged.
+func ICAOFromIATA(code string, mapping map[string]string) string {
+	code = strings.ToUpper(code)
+	if icao, ok := mapping[code]; ok {
+		return icao
+	}
+	if len(code) != 3 {
+		return code
+	}
+	for _, char := range code {
+		if char < 'A' || char > 'Z' {
+			return code
+		}
+	}
+	return "K" + code
+}
+
+// IsValidStationID reports whether id looks like a station ID, i.e. consists of 3 or 4 characters, starts with a letter
+// and otherwise only contains uppercase letters and digits.
+// The rules are deliberately loose, as station ID formats vary: besides 4-letter ICAO codes (e.g. "KORD") there are
+// 3-character US identifiers (e.g. "ORD") and IDs of small stations containing digits (e.g. "K0CO"). Thus, a mistyped
+// ID like "K0RD" can not be told apart from a valid one by its format alone.
+func IsValidStationID(id string) bool {
+	if len(id) != 3 && len(id) != 4 {
+		return false
+	}
+	for i, char := range id {
+		isLetter := char >= 'A' && char <= 'Z'
+		isDigit := char >= '0' && char <= '9'
+		if !isLetter && (i == 0 || !isDigit) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitStations splits a station string on commas and spaces
+func splitStations(value string) []string {
+	return strings.FieldsFunc(value, func(char rune) bool {
+		return char == ',' || char == ' '
+	})
+}
+
+// ExpandStationRange generates the station IDs consisting of prefix followed by every number from start to end
+// (inclusive), e.g. ["XY08", "XY09", "XY10"] for ExpandStationRange("XY", 8, 10).
+// The numbers are zero-padded to the amount of digits of end. nil is returned if start is greater than end or any of
+// them is negative.
+func ExpandStationRange(prefix string, start, end int) []string {
+	if start > end || start < 0 {
+		return nil
+	}
+
+	width := len(strconv.Itoa(end))
+	stations := make([]string, 0, end-start+1)
+	for number := start; number <= end; number++ {
+		stations = append(stations, fmt.Sprintf("%s%0*d", prefix, width, number))
+	}
+	return stations
+}
+
+// Station represents the metadata of a single reporting station
+type Station struct {
+	StationID  string  `xml:"station_id" json:"station_id"`
+	WMOID      string  `xml:"wmo_id" json:"wmo_id"`
+	Latitude   float32 `xml:"latitude" json:"latitude"`
+	Longitude  float32 `xml:"longitude" json:"longitude"`
+	ElevationM float32 `xml:"elevation_m" json:"elevation_m"`
+	Site       string  `xml:"site" json:"site"`
+	State      string  `xml:"state" json:"state"`
+	Country    string  `xml:"country" json:"country"`
+}
+
+// stationResponse represents the response that gets sent by the AWC Text Data Server for station queries
+type stationResponse struct {
+	XMLName  xml.Name   `xml:"response"`
+	Errors   []string   `xml:"errors>error"`
+	Warnings []string   `xml:"warnings>warning"`
+	Stations []*Station `xml:"data>Station"`
+}
+
+// maxStationsPerRequest is the maximum amount of stations whose metadata is requested at once, keeping the URL short
+const maxStationsPerRequest = 200
+
+// PreloadStations fetches the metadata of the given stations and caches it without returning it, requesting up to 200
+// stations at once.
+// This is intended to warm up the cache before enriching many METARs using GetStationInfo or GetMETARWithStations.
+// Stations that are cached already are not requested again. The cache lives as long as the client and is never evicted,
+// as station metadata hardly ever changes; use a new Client to start over.
+func (client *Client) PreloadStations(ctx context.Context, ids ...string) error {
+	client.stationsMutex.Lock()
+	var missing []string
+	for _, id := range ids {
+		if _, ok := client.stations[strings.ToUpper(id)]; !ok {
+			missing = appendUnique(missing, strings.ToUpper(id))
+		}
+	}
+	client.stationsMutex.Unlock()
+
+	for len(missing) > 0 {
+		batch := missing
+		if len(batch) > maxStationsPerRequest {
+			batch = batch[:maxStationsPerRequest]
+		}
+		missing = missing[len(batch):]
+
+		body, err := client.fetch(ctx, endpointStations.addString("stationString", strings.Join(batch, ",")))
+		if err != nil {
+			return err
+		}
+
+		response := new(stationResponse)
+		if err := decodeResponse(body, response); err != nil {
+			return err
+		}
+		if len(response.Errors) > 0 {
+			return errors.New(fmt.Sprintf("api error(s): %s", strings.Join(response.Errors, "; ")))
+		}
+
+		client.stationsMutex.Lock()
+		if client.stations == nil {
+			client.stations = make(map[string]*Station)
+		}
+		for _, station := range response.Stations {
+			client.stations[strings.ToUpper(station.StationID)] = station
+		}
+		client.stationsMutex.Unlock()
+	}
+	return nil
+}
+
+// GetStationInfo fetches the metadata of the given stations.
+// Fetched stations are cached by the client for its whole lifetime, so subsequent calls only request stations that
+// were not fetched before. Stations unknown to the server are omitted from the result.
+func (client *Client) GetStationInfo(ids ...string) ([]*Station, error) {
+	if err := client.PreloadStations(context.Background(), ids...); err != nil {
+		return nil, err
+	}
+
+	client.stationsMutex.Lock()
+	defer client.stationsMutex.Unlock()
+	stations := make([]*Station, 0, len(ids))
+	for _, id := range ids {
+		if station, ok := client.stations[strings.ToUpper(id)]; ok {
+			stations = append(stations, station)
+		}
+	}
+	return stations, nil
+}
+
+// GetMETARWithStations executes a METARQuery just like GetMETAR does and additionally sets the Station field of every
+// fetched METAR using GetStationInfo.
+// The coordinates and elevation of METARs lacking them are backfilled from the station metadata.
+func (client *Client) GetMETARWithStations(query *METARQuery) (*METARResponse, error) {
+	response, err := client.GetMETAR(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, metar := range response.METARs {
+		if metar.StationID != "" {
+			ids = appendUnique(ids, metar.StationID)
+		}
+	}
+	if len(ids) == 0 {
+		return response, nil
+	}
+
+	stations, err := client.GetStationInfo(ids...)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*Station, len(stations))
+	for _, station := range stations {
+		byID[strings.ToUpper(station.StationID)] = station
+	}
+
+	for _, metar := range response.METARs {
+		station, ok := byID[strings.ToUpper(metar.StationID)]
+		if !ok {
+			continue
+		}
+		metar.Station = station
+		if metar.Latitude == 0 && metar.Longitude == 0 {
+			metar.Latitude = station.Latitude
+			metar.Longitude = station.Longitude
+		}
+		if metar.ElevationM == 0 {
+			metar.ElevationM = station.ElevationM
+		}
+	}
+	return response, nil
+}
+
+// AroundStation builds a METARQuery fetching the METARs of all stations within the given radius in nautical miles
+// around the given station, whose coordinates are looked up using the station metadata cache (see PreloadStations).
+// Please keep in mind that RadialDistance takes statute miles, so the radius is converted and clamped to 500 statute
+// miles unless the returned query is made strict. An error is returned if the station is unknown to the server or
+// lacks coordinates.
+// Just like GetRouteBriefing, the returned query fetches the METARs of the last 3 hours; use HoursBeforeNow, Within or
+// Between to change that.
+func (client *Client) AroundStation(ctx context.Context, icao string, radiusNM float32) (*METARQuery, error) {
+	if err := client.PreloadStations(ctx, icao); err != nil {
+		return nil, err
+	}
+
+	client.stationsMutex.Lock()
+	station, ok := client.stations[strings.ToUpper(icao)]
+	client.stationsMutex.Unlock()
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("unknown station: %s", icao))
+	}
+	if station.Latitude == 0 && station.Longitude == 0 {
+		return nil, errors.New(fmt.Sprintf("station %s has no coordinates", icao))
+	}
+	return NewMETARQuery().
+		RadialDistance(radiusNM*smPerNM, station.Latitude, station.Longitude).
+		HoursBeforeNow(3), nil
+}
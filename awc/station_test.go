@@ -0,0 +1,84 @@
+package awc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testStationResponse = `<response>
+  <data num_results="1">
+    <Station>
+      <station_id>KSFO</station_id>
+      <latitude>37.62</latitude>
+      <longitude>-122.37</longitude>
+      <site>San Francisco Intl</site>
+    </Station>
+  </data>
+</response>`
+
+func TestAroundStation(t *testing.T) {
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		if strings.Contains(r.URL.RawQuery, "dataSource=stations") {
+			fmt.Fprint(w, testStationResponse)
+			return
+		}
+		serveMETARs(w, r)
+	})
+
+	query, err := client.AroundStation(context.Background(), "KSFO", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.GetMETAR(query); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	end := query.buildEndpoint().String()
+	if !strings.Contains(end, "hoursBeforeNow=3.000000&radialDistance=11.507790;-122.370003,37.619999") {
+		t.Errorf("unexpected endpoint: %s", end)
+	}
+	if end := query.Within(time.Hour).buildEndpoint().String(); !strings.Contains(end, "hoursBeforeNow=1.000000") {
+		t.Errorf("expected the time constraint to be replaceable, got endpoint %s", end)
+	}
+
+	if _, err := client.AroundStation(context.Background(), "KXXX", 10); err == nil {
+		t.Error("expected an error for an unknown station")
+	}
+}
+
+func TestICAOFromIATA(t *testing.T) {
+	mapping := map[string]string{"ANC": "PANC", "LHR": "EGLL"}
+	tests := []struct {
+		code, want string
+	}{
+		{"ORD", "KORD"},
+		{"ord", "KORD"},
+		{"ANC", "PANC"},
+		{"lhr", "EGLL"},
+		{"KORD", "KORD"},
+		{"O1D", "O1D"},
+		{"@CA", "@CA"},
+	}
+	for _, test := range tests {
+		if got := ICAOFromIATA(test.code, mapping); got != test.want {
+			t.Errorf("ICAOFromIATA(%q) = %q; want %q", test.code, got, test.want)
+		}
+	}
+}
+
+func TestTranslateIATA(t *testing.T) {
+	query := NewMETARQuery().Stations("ORD", "LHR", "EDDF").HoursBeforeNow(1).
+		TranslateIATA(true, map[string]string{"LHR": "EGLL"})
+	if end := query.buildEndpoint().String(); !strings.Contains(end, "stationString=KORD,EGLL,EDDF") {
+		t.Errorf("unexpected endpoint: %s", end)
+	}
+
+	query.TranslateIATA(false, nil)
+	if end := query.buildEndpoint().String(); !strings.Contains(end, "stationString=ORD,LHR,EDDF") {
+		t.Errorf("unexpected endpoint: %s", end)
+	}
+}
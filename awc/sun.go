@@ -0,0 +1,89 @@
+package awc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// sunriseElevation is the solar elevation in degrees at sunrise and sunset, accounting for atmospheric refraction and
+// the radius of the solar disc
+const sunriseElevation = -0.833
+
+// solarElevation approximates the elevation of the sun in degrees above the horizon at the given coordinates and time.
+// This follows the low-precision formulas of the Astronomical Almanac, determining sunrise and sunset within about a
+// minute.
+func solarElevation(lat, lon float64, at time.Time) float64 {
+	toRadians := func(degrees float64) float64 {
+		return degrees * math.Pi / 180
+	}
+
+	// days since J2000.0
+	days := float64(at.UTC().UnixNano())/float64(24*time.Hour) + 2440587.5 - 2451545.0
+
+	meanLongitude := 280.460 + 0.9856474*days
+	meanAnomaly := toRadians(357.528 + 0.9856003*days)
+	eclipticLongitude := toRadians(meanLongitude + 1.915*math.Sin(meanAnomaly) + 0.020*math.Sin(2*meanAnomaly))
+	obliquity := toRadians(23.439 - 0.0000004*days)
+
+	rightAscension := math.Atan2(math.Cos(obliquity)*math.Sin(eclipticLongitude), math.Cos(eclipticLongitude))
+	declination := math.Asin(math.Sin(obliquity) * math.Sin(eclipticLongitude))
+
+	siderealTime := toRadians(math.Mod(280.46061837+360.98564736629*days, 360) + lon)
+	hourAngle := siderealTime - rightAscension
+
+	latRadians := toRadians(lat)
+	elevation := math.Asin(math.Sin(latRadians)*math.Sin(declination) +
+		math.Cos(latRadians)*math.Cos(declination)*math.Cos(hourAngle))
+	return elevation * 180 / math.Pi
+}
+
+// IsDaytime reports whether the sun was above the horizon at the reporting station at the time of the observation,
+// i.e. whether the observation was made between sunrise and sunset.
+// This also covers polar day and night. An error is returned if the observation time can not be parsed or the
+// coordinates of the station are invalid; as the server omits the coordinates of some stations, 0/0 is considered
+// invalid as well.
+func (metar *METAR) IsDaytime() (bool, error) {
+	observedAt, err := metar.ObservedAt()
+	if err != nil {
+		return false, err
+	}
+	if err := metar.checkCoordinates(); err != nil {
+		return false, err
+	}
+	return solarElevation(float64(metar.Latitude), float64(metar.Longitude), observedAt) > sunriseElevation, nil
+}
+
+// checkCoordinates returns an error if the coordinates of the station are invalid; as the server omits the coordinates
+// of some stations, 0/0 is considered invalid as well
+func (metar *METAR) checkCoordinates() error {
+	if metar.Latitude < -90 || metar.Latitude > 90 || metar.Longitude < -180 || metar.Longitude > 180 ||
+		(metar.Latitude == 0 && metar.Longitude == 0) {
+		return errors.New(fmt.Sprintf("invalid station coordinates: %v/%v", metar.Latitude, metar.Longitude))
+	}
+	return nil
+}
+
+// LocalObservationTime returns the observation time in the approximate local time of the station.
+// The time zone is derived from the longitude of the station as a nautical time zone, i.e. a fixed offset of one hour
+// per 15 degrees, named like 'UTC-5'. This is a lightweight approximation without a time zone database: it is not
+// DST-aware and may be off by an hour or more where the legal time zone deviates from the longitude, e.g. in western
+// Europe or China. An error is returned if the observation time can not be parsed or the coordinates of the station
+// are invalid; please refer to IsDaytime for the latter.
+func (metar *METAR) LocalObservationTime() (time.Time, error) {
+	observedAt, err := metar.ObservedAt()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := metar.checkCoordinates(); err != nil {
+		return time.Time{}, err
+	}
+
+	offset := int(math.Round(float64(metar.Longitude) / 15))
+	name := "UTC"
+	if offset != 0 {
+		name = fmt.Sprintf("UTC%+d", offset)
+	}
+	return observedAt.In(time.FixedZone(name, offset*int(time.Hour/time.Second))), nil
+}
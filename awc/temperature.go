@@ -0,0 +1,78 @@
+package awc
+
+import "strings"
+
+// temperatureGroup returns the two halves of the temperature/dew point group of the raw METAR text, e.g. "M05" and
+// "M12" for 'M05/M12'.
+// Missing halves (empty or '//') are returned as empty strings; ok is false if the group is absent.
+func (metar *METAR) temperatureGroup() (temperature, dewPoint string, ok bool) {
+	for _, group := range metar.body() {
+		if temperature, dewPoint, ok = splitTemperatureGroup(group); ok {
+			return temperature, dewPoint, true
+		}
+	}
+	return "", "", false
+}
+
+// splitTemperatureGroup splits a temperature/dew point group like 'M05/M12' or '12///' into its halves.
+// Missing halves are returned as empty strings; ok is false if group is no temperature/dew point group.
+func splitTemperatureGroup(group string) (temperature, dewPoint string, ok bool) {
+	temperature, rest, ok := cutTemperatureValue(group)
+	if !ok || !strings.HasPrefix(rest, "/") {
+		return "", "", false
+	}
+	rest = rest[1:]
+	if rest == "" {
+		return temperature, "", true
+	}
+
+	dewPoint, rest, ok = cutTemperatureValue(rest)
+	if !ok || rest != "" {
+		return "", "", false
+	}
+	return temperature, dewPoint, true
+}
+
+// cutTemperatureValue cuts a whole-degree temperature like '05' or 'M12' or the '//' placeholder off the beginning of
+// value.
+// An empty temperature is returned for the placeholder.
+func cutTemperatureValue(value string) (temperature, rest string, ok bool) {
+	switch {
+	case strings.HasPrefix(value, "//"):
+		return "", value[2:], true
+	case len(value) >= 3 && value[0] == 'M' && isDigits(value[1:3]):
+		return value[:3], value[3:], true
+	case len(value) >= 2 && isDigits(value[:2]):
+		return value[:2], value[2:], true
+	default:
+		return "", "", false
+	}
+}
+
+// hasTemperatures reports whether the METAR contains the air temperature and dew point.
+// This is determined from the temperature group if the raw text is present; otherwise non-zero values are required.
+func (metar *METAR) hasTemperatures() (temperature, dewPoint bool) {
+	if metar.RawText == "" {
+		return metar.AirTempC != 0, metar.DewPointC != 0
+	}
+	temperatureValue, dewPointValue, _ := metar.temperatureGroup()
+	return temperatureValue != "", dewPointValue != ""
+}
+
+const (
+	// standardLapseRateCPer1000FT is the standard decrease of the temperature with altitude
+	standardLapseRateCPer1000FT = 2
+	feetPerMeter                = 3.28084
+)
+
+// FreezingLevelFT estimates the freezing level in feet above mean sea level from the air temperature and the elevation
+// of the station.
+// This assumes the standard lapse rate of 2 °C per 1000 feet, which may be far off in case of inversions or fronts;
+// the result is thus a rough estimate only. An ElevationM of 0 is treated as sea level. ok is false if the air
+// temperature is missing or at or below freezing, i.e. the freezing level is at or below the field elevation.
+func (metar *METAR) FreezingLevelFT() (float32, bool) {
+	if hasTemperature, _ := metar.hasTemperatures(); !hasTemperature || metar.AirTempC <= 0 {
+		return 0, false
+	}
+	return metar.ElevationM*feetPerMeter + metar.AirTempC/standardLapseRateCPer1000FT*1000, true
+}
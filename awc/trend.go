@@ -0,0 +1,235 @@
+package awc
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The trend types of a METAR trend forecast
+const (
+	TrendNOSIG = "NOSIG"
+	TrendBECMG = "BECMG"
+	TrendTEMPO = "TEMPO"
+)
+
+// Trend represents the trend forecast appended to a METAR, i.e. the expected changes within the next two hours
+type Trend struct {
+	// NoSignificantChange is true if the trend forecast is 'NOSIG'
+	NoSignificantChange bool
+	// Changes contains the BECMG and TEMPO groups in the order they were reported
+	Changes []TrendChange
+}
+
+// TrendChange represents a single BECMG (becoming) or TEMPO (temporarily) group of a trend forecast.
+// Only the conditions expected to change are set; all others are left at their zero value.
+type TrendChange struct {
+	Raw  string
+	Type string
+
+	// From, Until and At are the times of the FM, TL and AT groups in the format 'hhmm'
+	From  string
+	Until string
+	At    string
+
+	// WindDirDegrees is 0 for variable wind; WindVariable is set in this case
+	WindDirDegrees int
+	WindVariable   bool
+	WindSpeed      int
+	WindGust       int
+	// WindUnit is either "KT", "MPS" or "KMH"
+	WindUnit string
+
+	// Visibility is given in VisibilityUnit, which is either "M" (meters) or "SM" (statute miles).
+	// A metric visibility of 9999 denotes 10 kilometers or more; P6SM is reported as a visibility of 6 statute miles
+	// with VisibilityGreaterThan set.
+	Visibility            float32
+	VisibilityUnit        string
+	VisibilityGreaterThan bool
+
+	Weather []WeatherPhenomenon
+	// NoSignificantWeather is true if the end of the current weather is forecast ('NSW')
+	NoSignificantWeather bool
+
+	// Clouds contains the forecast cloud layers; IsCeiling is set for the lowest BKN, OVC or OVX layer
+	Clouds []CloudLayer
+	CAVOK  bool
+}
+
+func isTrendIndicator(group string) bool {
+	return group == TrendNOSIG || group == TrendBECMG || group == TrendTEMPO
+}
+
+// TrendForecast parses the trend forecast of the raw METAR text, which is mainly found in METARs outside of North
+// America.
+// nil is returned if no trend forecast is present; an error is returned if any group of the trend forecast can not be
+// parsed.
+func (metar *METAR) TrendForecast() (*Trend, error) {
+	fields := strings.Fields(metar.RawText)
+	start := -1
+	for i, field := range fields {
+		if field == "RMK" {
+			break
+		}
+		if isTrendIndicator(field) {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return nil, nil
+	}
+
+	trend := new(Trend)
+	var change *TrendChange
+	var raw []string
+	flush := func() {
+		if change != nil {
+			change.Raw = strings.Join(raw, " ")
+			markCeiling(change.Clouds)
+			trend.Changes = append(trend.Changes, *change)
+		}
+	}
+
+	groups := fields[start:]
+	for i := 0; i < len(groups); i++ {
+		group := groups[i]
+		if group == "RMK" {
+			break
+		}
+
+		switch group {
+		case TrendNOSIG:
+			flush()
+			change = nil
+			trend.NoSignificantChange = true
+			continue
+		case TrendBECMG, TrendTEMPO:
+			flush()
+			change = &TrendChange{Type: group}
+			raw = []string{group}
+			continue
+		}
+		if change == nil {
+			return nil, errors.New(fmt.Sprintf("unexpected trend group: %q", group))
+		}
+		raw = append(raw, group)
+
+		// visibilities like '1 1/2SM' span two groups
+		if i+1 < len(groups) && isDigits(group) && len(group) == 1 && strings.HasSuffix(groups[i+1], "SM") {
+			if err := change.parseVisibility(group + " " + groups[i+1]); err != nil {
+				return nil, err
+			}
+			raw = append(raw, groups[i+1])
+			i++
+			continue
+		}
+		if err := change.parseGroup(group); err != nil {
+			return nil, err
+		}
+	}
+	flush()
+	return trend, nil
+}
+
+// parseGroup parses a single group of a trend change
+func (change *TrendChange) parseGroup(group string) error {
+	switch {
+	case len(group) == 6 && isDigits(group[2:]) && strings.HasPrefix(group, "FM"):
+		change.From = group[2:]
+	case len(group) == 6 && isDigits(group[2:]) && strings.HasPrefix(group, "TL"):
+		change.Until = group[2:]
+	case len(group) == 6 && isDigits(group[2:]) && strings.HasPrefix(group, "AT"):
+		change.At = group[2:]
+	case group == "CAVOK":
+		change.CAVOK = true
+	case group == "NSW":
+		change.NoSignificantWeather = true
+	case group == "NSC" || group == "SKC" || group == "CLR":
+		change.Clouds = append(change.Clouds, CloudLayer{Cover: ParseSkyCover(group)})
+	case len(group) == 4 && isDigits(group):
+		visibility, _ := strconv.Atoi(group)
+		change.Visibility = float32(visibility)
+		change.VisibilityUnit = "M"
+	case strings.HasSuffix(group, "SM"):
+		return change.parseVisibility(group)
+	default:
+		if change.parseWind(group) || change.parseCloudLayer(group) {
+			return nil
+		}
+		if phenomenon, ok := decodeWeatherGroup(group); ok {
+			change.Weather = append(change.Weather, phenomenon)
+			return nil
+		}
+		return errors.New(fmt.Sprintf("unknown trend group: %q", group))
+	}
+	return nil
+}
+
+// parseWind parses a wind group like '24015G25KT', 'VRB03MPS' or '00000KT'
+func (change *TrendChange) parseWind(group string) bool {
+	values, ok := parseWindGroup(group)
+	if !ok {
+		return false
+	}
+	change.WindDirDegrees = values.direction
+	change.WindVariable = values.variable
+	change.WindSpeed = values.speed
+	change.WindGust = values.gust
+	change.WindUnit = values.unit
+	return true
+}
+
+// parseVisibility parses a visibility in statute miles like 'P6SM', '3SM', '1/2SM' or '1 1/2SM'
+func (change *TrendChange) parseVisibility(value string) error {
+	visibility, greaterThan, _, err := parseStatuteMiles(value)
+	if err != nil {
+		return err
+	}
+	change.Visibility = visibility
+	change.VisibilityUnit = "SM"
+	change.VisibilityGreaterThan = greaterThan
+	return nil
+}
+
+// parseCloudLayer parses a cloud layer like 'BKN015', 'OVC030CB' or a vertical visibility like 'VV002'
+func (change *TrendChange) parseCloudLayer(group string) bool {
+	var layer CloudLayer
+	rest := group
+	if strings.HasPrefix(rest, "VV") {
+		layer.Cover = SkyCoverOVX
+		rest = rest[2:]
+	} else if len(rest) >= 3 {
+		layer.Cover = ParseSkyCover(rest[:3])
+		rest = rest[3:]
+	}
+	if layer.Cover < SkyCoverFEW || len(rest) < 3 || !isDigits(rest[:3]) {
+		return false
+	}
+
+	base, _ := strconv.Atoi(rest[:3])
+	layer.BaseFT = base * 100
+	switch rest[3:] {
+	case "":
+	case "CB", "TCU":
+		layer.CloudType = rest[3:]
+	default:
+		return false
+	}
+	change.Clouds = append(change.Clouds, layer)
+	return true
+}
+
+// markCeiling sets IsCeiling for the lowest BKN, OVC or OVX layer
+func markCeiling(layers []CloudLayer) {
+	ceiling := -1
+	for i, layer := range layers {
+		if layer.Cover.isCeiling() && (ceiling < 0 || layer.BaseFT < layers[ceiling].BaseFT) {
+			ceiling = i
+		}
+	}
+	if ceiling >= 0 {
+		layers[ceiling].IsCeiling = true
+	}
+}
@@ -0,0 +1,77 @@
+package awc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTrendForecast(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want *Trend
+	}{
+		{"KSFO 011256Z 28012KT 10SM FEW020 15/08 A3002 RMK AO2", nil},
+		{"EGLL 011250Z 24012KT 9999 FEW030 12/08 Q1012 NOSIG", &Trend{NoSignificantChange: true}},
+		{"EGLL 011250Z 24012KT 9999 FEW030 12/08 Q1012 NOSIG RMK BECMG 3000", &Trend{NoSignificantChange: true}},
+		{"EHAM 011255Z 22015KT 9999 BKN030 13/08 Q1008 BECMG FM1330 TL1430 25020G32KT 6000 -RA BKN012",
+			&Trend{Changes: []TrendChange{{
+				Raw:  "BECMG FM1330 TL1430 25020G32KT 6000 -RA BKN012",
+				Type: TrendBECMG, From: "1330", Until: "1430",
+				WindDirDegrees: 250, WindSpeed: 20, WindGust: 32, WindUnit: "KT",
+				Visibility: 6000, VisibilityUnit: "M",
+				Weather: []WeatherPhenomenon{{Raw: "-RA", Intensity: "-", Phenomena: []string{"RA"}}},
+				Clouds:  []CloudLayer{{Cover: SkyCoverBKN, BaseFT: 1200, IsCeiling: true}},
+			}}}},
+		{"LFPG 011300Z 27015KT 9999 SCT035 14/09 Q1008 TEMPO 3000 TSRA SCT008 BKN015CB",
+			&Trend{Changes: []TrendChange{{
+				Raw:        "TEMPO 3000 TSRA SCT008 BKN015CB",
+				Type:       TrendTEMPO,
+				Visibility: 3000, VisibilityUnit: "M",
+				Weather: []WeatherPhenomenon{{Raw: "TSRA", Descriptor: "TS", Phenomena: []string{"RA"}}},
+				Clouds: []CloudLayer{
+					{Cover: SkyCoverSCT, BaseFT: 800},
+					{Cover: SkyCoverBKN, BaseFT: 1500, IsCeiling: true, CloudType: "CB"},
+				},
+			}}}},
+		{"EDDF 011250Z 28010KT 4000 -SHRA BKN008 11/07 Q1011 BECMG AT1400 NSW CAVOK TEMPO VRB15G30KT 1 1/2SM OVC004",
+			&Trend{Changes: []TrendChange{
+				{Raw: "BECMG AT1400 NSW CAVOK", Type: TrendBECMG, At: "1400", NoSignificantWeather: true, CAVOK: true},
+				{
+					Raw:  "TEMPO VRB15G30KT 1 1/2SM OVC004",
+					Type: TrendTEMPO, WindVariable: true, WindSpeed: 15, WindGust: 30, WindUnit: "KT",
+					Visibility: 1.5, VisibilityUnit: "SM",
+					Clouds: []CloudLayer{{Cover: SkyCoverOVC, BaseFT: 400, IsCeiling: true}},
+				},
+			}}},
+		{"UUEE 011300Z 18005MPS 9999 OVC020 05/02 Q1015 TEMPO P6SM NSC",
+			&Trend{Changes: []TrendChange{{
+				Raw: "TEMPO P6SM NSC", Type: TrendTEMPO,
+				Visibility: 6, VisibilityUnit: "SM", VisibilityGreaterThan: true,
+				Clouds: []CloudLayer{{Cover: SkyCoverNSC}},
+			}}}},
+	}
+	for _, test := range tests {
+		got, err := (&METAR{RawText: test.raw}).TrendForecast()
+		if err != nil {
+			t.Errorf("TrendForecast(%q) failed: %v", test.raw, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("TrendForecast(%q) = %+v; want %+v", test.raw, got, test.want)
+		}
+	}
+}
+
+func TestTrendForecastMalformed(t *testing.T) {
+	for _, raw := range []string{
+		"EGLL 011250Z 24012KT 9999 FEW030 12/08 Q1012 BECMG FM13 3000",
+		"EGLL 011250Z 24012KT 9999 FEW030 12/08 Q1012 TEMPO BKN01",
+		"EGLL 011250Z 24012KT 9999 FEW030 12/08 Q1012 TEMPO XX",
+		"EGLL 011250Z 24012KT 9999 FEW030 12/08 Q1012 TEMPO 1/0SM",
+		"EGLL 011250Z 24012KT 9999 FEW030 12/08 Q1012 NOSIG 3000",
+	} {
+		if trend, err := (&METAR{RawText: raw}).TrendForecast(); err == nil {
+			t.Errorf("TrendForecast(%q) = %+v; want an error", raw, trend)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package awc
+
+// UnitSystem represents a system of units derived METAR values can be converted to
+type UnitSystem int
+
+const (
+	// UnitSystemNone disables the conversion of METAR values
+	UnitSystemNone UnitSystem = iota
+	// UnitSystemImperial converts METAR values to °F, mph, statute miles and inHg
+	UnitSystemImperial
+	// UnitSystemMetric converts METAR values to °C, km/h, kilometers and hPa
+	UnitSystemMetric
+)
+
+const (
+	kmhPerKnot = 1.852
+	mphPerKnot = 1.150779
+	kmPerSM    = 1.609344
+	hPaPerInHG = 33.863886
+)
+
+func celsiusToFahrenheit(value float32) float32 {
+	return value*9/5 + 32
+}
+
+// METARConversions contains values of a METAR converted to a specific unit system.
+// Please refer to the documentation of the UnitSystem constants for the units used.
+type METARConversions struct {
+	Units      UnitSystem
+	AirTemp    float32
+	DewPoint   float32
+	WindSpeed  float32
+	WindGust   float32
+	Visibility float32
+	Altimeter  float32
+}
+
+// convert builds the METARConversions of the METAR for the given unit system.
+// nil is returned for UnitSystemNone.
+func (metar *METAR) convert(units UnitSystem) *METARConversions {
+	switch units {
+	case UnitSystemImperial:
+		return &METARConversions{
+			Units:      units,
+			AirTemp:    celsiusToFahrenheit(metar.AirTempC),
+			DewPoint:   celsiusToFahrenheit(metar.DewPointC),
+			WindSpeed:  float32(metar.WindSpeedKT) * mphPerKnot,
+			WindGust:   float32(metar.WindGustKT) * mphPerKnot,
+			Visibility: metar.VisibilityStatuteMI,
+			Altimeter:  metar.AltimeterInHG,
+		}
+	case UnitSystemMetric:
+		return &METARConversions{
+			Units:      units,
+			AirTemp:    metar.AirTempC,
+			DewPoint:   metar.DewPointC,
+			WindSpeed:  float32(metar.WindSpeedKT) * kmhPerKnot,
+			WindGust:   float32(metar.WindGustKT) * kmhPerKnot,
+			Visibility: metar.VisibilityStatuteMI * kmPerSM,
+			Altimeter:  metar.AltimeterInHG * hPaPerInHG,
+		}
+	default:
+		return nil
+	}
+}
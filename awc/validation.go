@@ -0,0 +1,130 @@
+package awc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrMissingTimeConstraint is returned if a METARQuery specifies neither HoursBeforeNow nor Between
+var ErrMissingTimeConstraint = errors.New("missing time constraint: either HoursBeforeNow or Between is required")
+
+// ErrInvalidTimeRange is returned if the start time of the timespan specified using Between is after its end time
+var ErrInvalidTimeRange = errors.New("invalid time range: start time is after the end time")
+
+// ValidationError represents a single problem of a METARQuery found by Validate
+type ValidationError struct {
+	// Parameter is the name of the affected query parameter, e.g. "startTime", "minLat", "station" or "fields".
+	// The names of out-of-range values match the ones of QueryAdjustment.
+	Parameter string
+	Message   string
+	// Err is the sentinel error the problem corresponds to, i.e. ErrMissingTimeConstraint or ErrInvalidTimeRange; it is
+	// nil for all other problems
+	Err error
+}
+
+func (err *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", err.Parameter, err.Message)
+}
+
+// Unwrap returns the sentinel error the problem corresponds to, allowing to match it using errors.Is
+func (err *ValidationError) Unwrap() error {
+	return err.Err
+}
+
+// Validate checks the whole query and returns all problems found as *ValidationError values in one pass.
+// The following problems are reported:
+//   - a missing time constraint (Parameter "hoursBeforeNow", wrapping ErrMissingTimeConstraint), as either
+//     HoursBeforeNow or Between is required
+//   - a start time after the end time of Between (Parameter "startTime", wrapping ErrInvalidTimeRange)
+//   - a minimum latitude or longitude of InRectangle greater than the maximum one (Parameter "minLat" or "minLon")
+//   - a station string without any station (Parameter "station")
+//   - every station ID not passing IsValidStationID after the IATA translation and converting it to uppercase,
+//     except for state ('@') and country ('~') selectors (Parameter "station")
+//   - every out-of-range value that was clamped, as returned by Adjustments
+//   - every unknown field name (Parameter "fields")
+//
+// Unlike the query execution, Validate reports all of these regardless of Strict.
+//
+// Validate returns a slice rather than a single error so that every problem can be mapped to the affected parameter at
+// once. As the time constraint problems wrap ErrMissingTimeConstraint and ErrInvalidTimeRange, errors.Is works on them
+// just like on the error returned when executing the query, e.g. by GetMETAR.
+func (query *METARQuery) Validate() []error {
+	var problems []error
+	addProblem := func(parameter, format string, args ...interface{}) {
+		problems = append(problems, &ValidationError{Parameter: parameter, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if err := query.checkTimeConstraint(); err != nil {
+		problems = append(problems, err)
+	}
+	if query.rectMinLat != nil {
+		if *query.rectMinLat > *query.rectMaxLat {
+			addProblem("minLat", "%f is greater than the maximum latitude %f", *query.rectMinLat, *query.rectMaxLat)
+		}
+		if *query.rectMinLon > *query.rectMaxLon {
+			addProblem("minLon", "%f is greater than the maximum longitude %f", *query.rectMinLon, *query.rectMaxLon)
+		}
+	}
+	if query.station != nil && len(splitStations(*query.station)) == 0 {
+		addProblem("station", "no station specified")
+	}
+	for _, station := range query.invalidStations() {
+		addProblem("station", "invalid station ID: %s", station)
+	}
+	for _, adjustment := range query.adjustments {
+		addProblem(adjustment.Parameter, "%f is out of range and was adjusted to %f", adjustment.Value,
+			adjustment.AdjustedValue)
+	}
+	for _, field := range query.unknownFields() {
+		addProblem("fields", "unknown METAR field: %s", field)
+	}
+	return problems
+}
+
+// checkTimeConstraint returns a *ValidationError wrapping ErrMissingTimeConstraint or ErrInvalidTimeRange if the time
+// constraint of the query is missing or invalid
+func (query *METARQuery) checkTimeConstraint() error {
+	switch {
+	case query.startTime == nil && query.hoursBeforeNow == nil:
+		return &ValidationError{
+			Parameter: "hoursBeforeNow",
+			Message:   ErrMissingTimeConstraint.Error(),
+			Err:       ErrMissingTimeConstraint,
+		}
+	case query.startTime != nil && *query.startTime > *query.endTime:
+		return &ValidationError{Parameter: "startTime", Message: ErrInvalidTimeRange.Error(), Err: ErrInvalidTimeRange}
+	}
+	return nil
+}
+
+// invalidStations returns the station IDs of the station string not passing IsValidStationID after the IATA
+// translation and converting them to uppercase; state ('@') and country ('~') selectors are passed through
+func (query *METARQuery) invalidStations() []string {
+	if query.station == nil {
+		return nil
+	}
+	var invalid []string
+	for _, station := range splitStations(*query.station) {
+		if query.translateIATA {
+			station = ICAOFromIATA(station, query.iataMapping)
+		}
+		// the server accepts station IDs regardless of their case
+		normalized := strings.ToUpper(station)
+		if !IsValidStationID(normalized) && !strings.HasPrefix(station, "@") && !strings.HasPrefix(station, "~") {
+			invalid = append(invalid, station)
+		}
+	}
+	return invalid
+}
+
+// unknownFields returns the field names passed to Fields not passing isMETARField
+func (query *METARQuery) unknownFields() []string {
+	var unknown []string
+	for _, field := range query.fields {
+		if !isMETARField(field) {
+			unknown = append(unknown, field)
+		}
+	}
+	return unknown
+}
@@ -0,0 +1,72 @@
+package awc
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetMETARValidatesTimeConstraint(t *testing.T) {
+	var requests int32
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		serveMETARs(w, r)
+	})
+	start := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		query *METARQuery
+		want  error
+	}{
+		{"missing", NewMETARQuery().Station("KSFO"), ErrMissingTimeConstraint},
+		{"inverted", NewMETARQuery().Station("KSFO").Between(start, start.Add(-time.Hour)), ErrInvalidTimeRange},
+	}
+	for _, test := range tests {
+		if _, err := client.GetMETAR(test.query); !errors.Is(err, test.want) {
+			t.Errorf("%s: got error %v; want %v", test.name, err, test.want)
+		}
+		if _, err := client.GetMETARs(test.query); !errors.Is(err, test.want) {
+			t.Errorf("%s: GetMETARs returned error %v; want %v", test.name, err, test.want)
+		}
+
+		problems := test.query.Validate()
+		if len(problems) != 1 || !errors.Is(problems[0], test.want) {
+			t.Errorf("%s: Validate() = %v; want a single problem wrapping %v", test.name, problems, test.want)
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 0 {
+		t.Errorf("expected no request to be sent, got %d", n)
+	}
+
+	if _, err := client.GetMETAR(NewMETARQuery().Station("KSFO").Between(start, start)); err != nil {
+		t.Errorf("unexpected error for an empty timespan: %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("expected a valid query to be sent, got %d requests", n)
+	}
+}
+
+func TestValidateReportsAllProblems(t *testing.T) {
+	query := NewMETARQuery().Stations("KSFO", "K$FO").InRectangle(40, -120, 30, -125).Fields("raw_text", "bogus")
+	var parameters []string
+	for _, problem := range query.Validate() {
+		var validationErr *ValidationError
+		if !errors.As(problem, &validationErr) {
+			t.Fatalf("expected a *ValidationError, got %T", problem)
+		}
+		parameters = append(parameters, validationErr.Parameter)
+	}
+	want := []string{"hoursBeforeNow", "minLat", "minLon", "station", "fields"}
+	if len(parameters) != len(want) {
+		t.Fatalf("got problems for %v; want %v", parameters, want)
+	}
+	for i := range want {
+		if parameters[i] != want[i] {
+			t.Errorf("got problems for %v; want %v", parameters, want)
+			break
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package awc
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RVR represents a single runway visual range group of a METAR, e.g. 'R22L/1000V1600FT/U'
+type RVR struct {
+	// Runway is the runway designator without the 'R' prefix, e.g. "22L"
+	Runway string
+	// MinRange is the (minimum) visual range; it equals MaxRange if the range is not variable
+	MinRange int
+	// MaxRange is the (maximum) visual range; it equals MinRange if the range is not variable
+	MaxRange int
+	// LessThan indicates that MinRange is below the lowest value the system can measure ('M' prefix)
+	LessThan bool
+	// GreaterThan indicates that MaxRange is above the highest value the system can measure ('P' prefix)
+	GreaterThan bool
+	// Unit is either "FT" or "M"
+	Unit string
+	// Trend is either "U" (upward), "D" (downward), "N" (no change) or empty if not reported
+	Trend string
+}
+
+// RunwayVisualRanges parses the runway visual range groups of the raw METAR text.
+// Both the US (feet, e.g. 'R22L/2400FT') and the ICAO (meters, e.g. 'R22L/0600U') notations are supported.
+func (metar *METAR) RunwayVisualRanges() []RVR {
+	var ranges []RVR
+	for _, group := range metar.body() {
+		if rvr, ok := parseRVR(group); ok {
+			ranges = append(ranges, rvr)
+		}
+	}
+	return ranges
+}
+
+// parseRVR parses a runway visual range group like 'R22L/2400FT', 'R04R/1400V2400FT' or 'R27L/M0050D'.
+// The values have to consist of exactly 4 digits, which tells them apart from runway state groups like 'R24L/290195'.
+func parseRVR(group string) (RVR, bool) {
+	parts := strings.Split(group, "/")
+	if len(parts) < 2 || len(parts) > 3 || len(parts[0]) < 3 || parts[0][0] != 'R' || !isDigits(parts[0][1:3]) ||
+		len(strings.TrimRight(parts[0][3:], "LCR")) != 0 || parts[1] == "" {
+		return RVR{}, false
+	}
+
+	rvr := RVR{Runway: parts[0][1:], Unit: "M"}
+
+	value := parts[1]
+	if len(parts) == 3 {
+		rvr.Trend = parts[2]
+	} else if last := value[len(value)-1:]; last == "U" || last == "D" || last == "N" {
+		rvr.Trend = last
+		value = value[:len(value)-1]
+	}
+	switch rvr.Trend {
+	case "", "U", "D", "N":
+	default:
+		return RVR{}, false
+	}
+
+	if strings.HasSuffix(value, "FT") {
+		rvr.Unit = "FT"
+		value = strings.TrimSuffix(value, "FT")
+	}
+
+	minValue, maxValue := value, value
+	if split := strings.Index(value, "V"); split >= 0 {
+		minValue, maxValue = value[:split], value[split+1:]
+	}
+
+	var ok bool
+	if rvr.MinRange, rvr.LessThan, ok = parseRVRValue(minValue, "M"); !ok {
+		return RVR{}, false
+	}
+	if rvr.MaxRange, rvr.GreaterThan, ok = parseRVRValue(maxValue, "P"); !ok {
+		return RVR{}, false
+	}
+	return rvr, true
+}
+
+// parseRVRValue parses a single 4-digit visual range value, optionally preceded by one of the 'M' or 'P' modifiers.
+// exceeds reports whether the value carried the given modifier.
+func parseRVRValue(value, modifier string) (rangeValue int, exceeds bool, ok bool) {
+	exceeds = strings.HasPrefix(value, modifier)
+	if strings.HasPrefix(value, "M") || strings.HasPrefix(value, "P") {
+		value = value[1:]
+	}
+	if len(value) != 4 || !isDigits(value) {
+		return 0, false, false
+	}
+	rangeValue, _ = strconv.Atoi(value)
+	return rangeValue, exceeds, true
+}
+
+// VisibilityCategoryOf buckets a visibility in statute miles, aligned with the FAA flight category thresholds:
+// "<1SM" (below 1), "1-3SM" (1 to below 3), "3-5SM" (3 to 5), "5-10SM" (above 5 to below 10) and "10SM+" (10 and
+// above, as the server caps reported visibilities at 10).
+func VisibilityCategoryOf(visibilitySM float32) string {
+	switch {
+	case visibilitySM < 1:
+		return "<1SM"
+	case visibilitySM < 3:
+		return "1-3SM"
+	case visibilitySM <= 5:
+		return "3-5SM"
+	case visibilitySM < 10:
+		return "5-10SM"
+	default:
+		return "10SM+"
+	}
+}
+
+// VisibilityCategory buckets the visibility of the METAR.
+// An empty string is returned if the METAR contains no visibility.
+// Please refer to VisibilityCategoryOf for the buckets.
+func (metar *METAR) VisibilityCategory() string {
+	if !metar.hasVisibility() {
+		return ""
+	}
+	return VisibilityCategoryOf(metar.VisibilityStatuteMI)
+}
+
+// parseStatuteMiles parses a visibility in statute miles like 'P6SM', 'M1/4SM', '3SM', '1/2SM' or '1 1/2SM'
+func parseStatuteMiles(value string) (visibility float32, greaterThan, lessThan bool, err error) {
+	invalid := errors.New(fmt.Sprintf("invalid visibility: %q", value))
+	rest := strings.TrimSuffix(value, "SM")
+	if rest == value {
+		return 0, false, false, invalid
+	}
+	if strings.HasPrefix(rest, "P") {
+		greaterThan = true
+		rest = rest[1:]
+	} else if strings.HasPrefix(rest, "M") {
+		lessThan = true
+		rest = rest[1:]
+	}
+
+	parts := strings.Fields(rest)
+	if len(parts) == 0 {
+		return 0, false, false, invalid
+	}
+	for _, part := range parts {
+		numerator, denominator := part, ""
+		if slash := strings.Index(part, "/"); slash >= 0 {
+			numerator, denominator = part[:slash], part[slash+1:]
+		}
+		if !isDigits(numerator) {
+			return 0, false, false, invalid
+		}
+		whole, _ := strconv.Atoi(numerator)
+		if denominator == "" {
+			visibility += float32(whole)
+			continue
+		}
+		if !isDigits(denominator) {
+			return 0, false, false, invalid
+		}
+		divisor, _ := strconv.Atoi(denominator)
+		if divisor == 0 {
+			return 0, false, false, invalid
+		}
+		visibility += float32(whole) / float32(divisor)
+	}
+	return visibility, greaterThan, lessThan, nil
+}
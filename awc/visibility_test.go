@@ -0,0 +1,112 @@
+package awc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRVR(t *testing.T) {
+	tests := []struct {
+		group string
+		want  RVR
+		ok    bool
+	}{
+		{"R22L/2400FT", RVR{Runway: "22L", MinRange: 2400, MaxRange: 2400, Unit: "FT"}, true},
+		{"R04R/1400V2400FT", RVR{Runway: "04R", MinRange: 1400, MaxRange: 2400, Unit: "FT"}, true},
+		{"R22L/P6000FT", RVR{Runway: "22L", MinRange: 6000, MaxRange: 6000, GreaterThan: true, Unit: "FT"}, true},
+		{"R28/M0600FT", RVR{Runway: "28", MinRange: 600, MaxRange: 600, LessThan: true, Unit: "FT"}, true},
+		{"R22L/1000V1600FT/U", RVR{Runway: "22L", MinRange: 1000, MaxRange: 1600, Unit: "FT", Trend: "U"}, true},
+		{"R27L/0600U", RVR{Runway: "27L", MinRange: 600, MaxRange: 600, Unit: "M", Trend: "U"}, true},
+		{"R27R/P2000", RVR{Runway: "27R", MinRange: 2000, MaxRange: 2000, GreaterThan: true, Unit: "M"}, true},
+		{"R09/M0050D", RVR{Runway: "09", MinRange: 50, MaxRange: 50, LessThan: true, Unit: "M", Trend: "D"}, true},
+		{"R24L/0500VP1500N", RVR{Runway: "24L", MinRange: 500, MaxRange: 1500, GreaterThan: true, Unit: "M",
+			Trend: "N"}, true},
+		// runway state groups
+		{"R24L/290195", RVR{}, false},
+		{"R88/CLRD70", RVR{}, false},
+		{"R24/490155", RVR{}, false},
+		// malformed groups
+		{"R22L/240FT", RVR{}, false},
+		{"R22L/24000FT", RVR{}, false},
+		{"R22X/2400FT", RVR{}, false},
+		{"R22L/2400FT/X", RVR{}, false},
+		{"R22L/", RVR{}, false},
+		{"RMK", RVR{}, false},
+	}
+	for _, test := range tests {
+		got, ok := parseRVR(test.group)
+		if ok != test.ok || got != test.want {
+			t.Errorf("parseRVR(%q) = %+v, %t; want %+v, %t", test.group, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestRunwayVisualRanges(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want []RVR
+	}{
+		{
+			"KJFK 011251Z 04008KT 1/4SM R04R/1400V2400FT R22L/P6000FT FG VV002 10/10 A2990",
+			[]RVR{
+				{Runway: "04R", MinRange: 1400, MaxRange: 2400, Unit: "FT"},
+				{Runway: "22L", MinRange: 6000, MaxRange: 6000, GreaterThan: true, Unit: "FT"},
+			},
+		},
+		{
+			"EGLL 011250Z 27005KT 0400 R27L/0600U R27R/0550N FG VV001 08/08 Q1012",
+			[]RVR{
+				{Runway: "27L", MinRange: 600, MaxRange: 600, Unit: "M", Trend: "U"},
+				{Runway: "27R", MinRange: 550, MaxRange: 550, Unit: "M", Trend: "N"},
+			},
+		},
+		{"ESSA 011250Z 01005KT 9999 BKN012 M02/M04 Q1003 R19R/290195 R26/CLRD70", nil},
+	}
+	for _, test := range tests {
+		if got := (&METAR{RawText: test.raw}).RunwayVisualRanges(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("RunwayVisualRanges(%q) = %+v; want %+v", test.raw, got, test.want)
+		}
+	}
+}
+
+func TestRunwayStateGroupIsNoRVR(t *testing.T) {
+	metar := &METAR{RawText: "EFHK 011250Z 33008KT 9999 -SN BKN015 M05/M08 Q1011 R24L/290195"}
+	if ranges := metar.RunwayVisualRanges(); len(ranges) != 0 {
+		t.Errorf("unexpected runway visual ranges: %+v", ranges)
+	}
+	conditions := metar.RunwayConditions()
+	if len(conditions) != 1 || conditions[0].Runway != "24L" || conditions[0].BrakingAction != "good" {
+		t.Errorf("unexpected runway conditions: %+v", conditions)
+	}
+}
+
+func TestVisibilityCategoryOf(t *testing.T) {
+	tests := []struct {
+		visibilitySM float32
+		want         string
+	}{
+		{0, "<1SM"},
+		{0.25, "<1SM"},
+		{0.99, "<1SM"},
+		{1, "1-3SM"},
+		{2.5, "1-3SM"},
+		{3, "3-5SM"},
+		{5, "3-5SM"},
+		{5.5, "5-10SM"},
+		{9.99, "5-10SM"},
+		{10, "10SM+"},
+		{15, "10SM+"},
+	}
+	for _, test := range tests {
+		if got := VisibilityCategoryOf(test.visibilitySM); got != test.want {
+			t.Errorf("VisibilityCategoryOf(%v) = %q; want %q", test.visibilitySM, got, test.want)
+		}
+	}
+
+	if got := (&METAR{}).VisibilityCategory(); got != "" {
+		t.Errorf("VisibilityCategory() without visibility = %q; want empty", got)
+	}
+	if got := (&METAR{VisibilityStatuteMI: 10}).VisibilityCategory(); got != "10SM+" {
+		t.Errorf("VisibilityCategory() = %q; want 10SM+", got)
+	}
+}
@@ -0,0 +1,214 @@
+package awc
+
+import "strings"
+
+var weatherDescriptors = map[string]string{
+	"MI": "shallow",
+	"PR": "partial",
+	"BC": "patches of",
+	"DR": "low drifting",
+	"BL": "blowing",
+	"SH": "showers",
+	"TS": "thunderstorm",
+	"FZ": "freezing",
+}
+
+var weatherPhenomena = map[string]string{
+	"DZ": "drizzle",
+	"RA": "rain",
+	"SN": "snow",
+	"SG": "snow grains",
+	"IC": "ice crystals",
+	"PL": "ice pellets",
+	"GR": "hail",
+	"GS": "small hail",
+	"UP": "unknown precipitation",
+	"BR": "mist",
+	"FG": "fog",
+	"FU": "smoke",
+	"VA": "volcanic ash",
+	"DU": "widespread dust",
+	"SA": "sand",
+	"HZ": "haze",
+	"PY": "spray",
+	"PO": "dust whirls",
+	"SQ": "squalls",
+	"FC": "funnel cloud",
+	"SS": "sandstorm",
+	"DS": "duststorm",
+}
+
+// WeatherPhenomenon represents a single decoded weather group, e.g. '-SHRA' or 'VCTS'
+type WeatherPhenomenon struct {
+	// Raw is the group as written in the report
+	Raw string
+	// Intensity is either "-" (light), "+" (heavy) or empty (moderate)
+	Intensity string
+	// Vicinity indicates that the phenomenon was observed in the vicinity ('VC') rather than at the station
+	Vicinity bool
+	// Descriptor is the optional two-letter descriptor, e.g. "SH" or "TS"
+	Descriptor string
+	// Phenomena contains the two-letter codes of all phenomena of the group, e.g. ["RA", "SN"] for 'RASN'
+	Phenomena []string
+}
+
+// Description renders the phenomenon in plain English, e.g. "light showers of rain" for '-SHRA'
+func (phenomenon WeatherPhenomenon) Description() string {
+	var names []string
+	for _, code := range phenomenon.Phenomena {
+		names = append(names, weatherPhenomena[code])
+	}
+	description := strings.Join(names, " and ")
+
+	switch phenomenon.Descriptor {
+	case "":
+	case "SH":
+		description = joinNonEmpty(" of ", "showers", description)
+	case "TS":
+		description = joinNonEmpty(" with ", "thunderstorm", description)
+	default:
+		description = joinNonEmpty(" ", weatherDescriptors[phenomenon.Descriptor], description)
+	}
+
+	switch phenomenon.Intensity {
+	case "-":
+		description = "light " + description
+	case "+":
+		description = "heavy " + description
+	}
+
+	if phenomenon.Vicinity {
+		description += " in the vicinity"
+	}
+	return description
+}
+
+func joinNonEmpty(separator, first, second string) string {
+	if second == "" {
+		return first
+	}
+	return first + separator + second
+}
+
+// DecodeWeather decodes a weather string like the WXString field of a METAR (e.g. "-SHRA BR") into its groups.
+// Every group is split into intensity, proximity, descriptor and any number of phenomena, so compound groups like
+// '+TSRA' or 'RASN' are supported. Groups that can not be decoded are skipped.
+func DecodeWeather(value string) []WeatherPhenomenon {
+	var phenomena []WeatherPhenomenon
+	for _, group := range strings.Fields(value) {
+		if phenomenon, ok := decodeWeatherGroup(group); ok {
+			phenomena = append(phenomena, phenomenon)
+		}
+	}
+	return phenomena
+}
+
+func decodeWeatherGroup(group string) (WeatherPhenomenon, bool) {
+	phenomenon := WeatherPhenomenon{Raw: group}
+
+	rest := group
+	if strings.HasPrefix(rest, "-") || strings.HasPrefix(rest, "+") {
+		phenomenon.Intensity = rest[:1]
+		rest = rest[1:]
+	}
+	if strings.HasPrefix(rest, "VC") {
+		phenomenon.Vicinity = true
+		rest = rest[2:]
+	}
+	if len(rest) >= 2 {
+		if _, ok := weatherDescriptors[rest[:2]]; ok {
+			phenomenon.Descriptor = rest[:2]
+			rest = rest[2:]
+		}
+	}
+
+	if len(rest)%2 != 0 {
+		return WeatherPhenomenon{}, false
+	}
+	for i := 0; i < len(rest); i += 2 {
+		code := rest[i : i+2]
+		if _, ok := weatherPhenomena[code]; !ok {
+			return WeatherPhenomenon{}, false
+		}
+		phenomenon.Phenomena = append(phenomenon.Phenomena, code)
+	}
+
+	if phenomenon.Descriptor == "" && len(phenomenon.Phenomena) == 0 {
+		return WeatherPhenomenon{}, false
+	}
+	return phenomenon, true
+}
+
+// Weather decodes the WXString field of the METAR.
+// Please refer to DecodeWeather for further information and use WeatherReliable to check whether an empty result
+// actually means that no weather was observed.
+func (metar *METAR) Weather() []WeatherPhenomenon {
+	return DecodeWeather(metar.WXString)
+}
+
+// RecentWeather decodes the recent weather groups ('RE' prefix, e.g. 'RETS' or 'RESHRA') of the raw METAR text.
+// These describe phenomena that ended since the last report and are thus distinct from the current weather; the Raw
+// field of the returned phenomena still contains the 'RE' prefix.
+func (metar *METAR) RecentWeather() []WeatherPhenomenon {
+	var phenomena []WeatherPhenomenon
+	for _, group := range metar.body() {
+		if len(group) < 4 || !strings.HasPrefix(group, "RE") {
+			continue
+		}
+		if phenomenon, ok := decodeWeatherGroup(group[2:]); ok {
+			phenomenon.Raw = group
+			phenomena = append(phenomena, phenomenon)
+		}
+	}
+	return phenomena
+}
+
+// dominantWeatherPriority ranks the weather codes used by StatusCode from the most to the least significant one
+var dominantWeatherPriority = []string{
+	"TS", "FC", "SS", "DS", "SQ", "GR", "GS", "PL", "SN", "SG", "RA", "DZ", "UP", "IC",
+	"FG", "VA", "FU", "DU", "SA", "HZ", "BR", "PY", "PO",
+}
+
+// dominantWeather returns the most significant weather code observed at the station according to
+// dominantWeatherPriority
+func (metar *METAR) dominantWeather() string {
+	dominant := -1
+	for _, phenomenon := range metar.Weather() {
+		if phenomenon.Vicinity {
+			continue
+		}
+		codes := phenomenon.Phenomena
+		if phenomenon.Descriptor == "TS" {
+			codes = append([]string{"TS"}, codes...)
+		}
+		for _, code := range codes {
+			for priority, candidate := range dominantWeatherPriority {
+				if candidate == code && (dominant < 0 || priority < dominant) {
+					dominant = priority
+				}
+			}
+		}
+	}
+	if dominant < 0 {
+		return ""
+	}
+	return dominantWeatherPriority[dominant]
+}
+
+// StatusCode returns a compact status of the current conditions combining the flight category and the dominant weather
+// phenomenon, e.g. "VFR", "IFR-RA" or "LIFR-SN".
+// The flight category is computed using ComputeFlightCategory if the server omitted it; FlightCategoryUnknown is
+// used if it is still unknown. The dominant weather is the most significant phenomenon observed at the station (not
+// in its vicinity), ranked thunderstorms (TS) first, followed by funnel clouds, sand and dust storms, squalls, frozen
+// precipitation, rain and drizzle and finally obscurations like fog, mist and haze. Intensities and descriptors other
+// than TS are omitted.
+func (metar *METAR) StatusCode() string {
+	category := metar.flightCategory()
+	if flightCategorySeverity(category) == 0 {
+		category = FlightCategoryUnknown
+	}
+	if weather := metar.dominantWeather(); weather != "" {
+		return category + "-" + weather
+	}
+	return category
+}
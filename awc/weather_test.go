@@ -0,0 +1,58 @@
+package awc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStatusCode(t *testing.T) {
+	tests := []struct {
+		metar *METAR
+		want  string
+	}{
+		{&METAR{FlightCategory: FlightCategoryVFR}, "VFR"},
+		{&METAR{FlightCategory: FlightCategoryIFR, WXString: "-RA BR"}, "IFR-RA"},
+		{&METAR{FlightCategory: FlightCategoryLIFR, WXString: "+SN FG"}, "LIFR-SN"},
+		{&METAR{FlightCategory: FlightCategoryMVFR, WXString: "VCTS -RA"}, "MVFR-RA"},
+		{&METAR{}, FlightCategoryUnknown},
+		{&METAR{WXString: "RA"}, FlightCategoryUnknown + "-RA"},
+	}
+	for _, test := range tests {
+		if got := test.metar.StatusCode(); got != test.want {
+			t.Errorf("StatusCode() of %+v = %q; want %q", test.metar, got, test.want)
+		}
+	}
+
+	groups := (&METARResponse{METARs: []*METAR{{}}}).GroupByFlightCategory()
+	if len(groups[FlightCategoryUnknown]) != 1 {
+		t.Errorf("expected GroupByFlightCategory to use FlightCategoryUnknown, got %v", groups)
+	}
+}
+
+func TestRecentWeather(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want []WeatherPhenomenon
+	}{
+		{"EGLL 011250Z 24012KT 9999 FEW030 12/08 Q1012 RERA NOSIG",
+			[]WeatherPhenomenon{{Raw: "RERA", Phenomena: []string{"RA"}}}},
+		{"LFPG 011300Z 27015G28KT 9999 SCT035CB 14/09 Q1008 RETS NOSIG",
+			[]WeatherPhenomenon{{Raw: "RETS", Descriptor: "TS"}}},
+		{"EDDF 011250Z 28010KT 9999 -SHRA FEW025CB 11/07 Q1011 RESHRA RETSRA BECMG NSW",
+			[]WeatherPhenomenon{
+				{Raw: "RESHRA", Descriptor: "SH", Phenomena: []string{"RA"}},
+				{Raw: "RETSRA", Descriptor: "TS", Phenomena: []string{"RA"}},
+			}},
+		{"ESSA 011250Z 36008KT 5000 -SN OVC012 M02/M04 Q1002 REFZDZ R01L/290050",
+			[]WeatherPhenomenon{{Raw: "REFZDZ", Descriptor: "FZ", Phenomena: []string{"DZ"}}}},
+		{"EGLL 011250Z 24012KT 9999 FEW030 12/08 Q1012 TEMPO 4000 RERA", nil},
+		{"KSFO 011256Z 28012KT 10SM FEW020 15/08 A3002 RMK AO2 RAE45 RERA", nil},
+		{"EGLL 011250Z 24012KT 9999 FEW030 12/08 Q1012 REXX RE NOSIG", nil},
+		{"EGLL 011250Z 24012KT 9999 -RA FEW030 12/08 Q1012 NOSIG", nil},
+	}
+	for _, test := range tests {
+		if got := (&METAR{RawText: test.raw}).RecentWeather(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("RecentWeather(%q) = %+v; want %+v", test.raw, got, test.want)
+		}
+	}
+}
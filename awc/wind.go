@@ -0,0 +1,294 @@
+package awc
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WindShearWarnings extracts the low-level wind shear groups ('WS') of the raw METAR text.
+// Every returned entry describes the affected runway(s) as written in the report, e.g. "RWY22", "TKOF RWY04L" or
+// "ALL RWY".
+func (metar *METAR) WindShearWarnings() []string {
+	groups := metar.body()
+
+	var warnings []string
+	for i := 0; i < len(groups); i++ {
+		if groups[i] != "WS" {
+			continue
+		}
+
+		var parts []string
+		for j := i + 1; j < len(groups); j++ {
+			group := groups[j]
+			if group == "ALL" || group == "TKOF" || group == "LDG" {
+				parts = append(parts, group)
+				continue
+			}
+			if isRunwayDesignator(group) {
+				parts = append(parts, group)
+			}
+			break
+		}
+
+		if len(parts) > 0 {
+			warnings = append(warnings, strings.Join(parts, " "))
+			i += len(parts)
+		}
+	}
+	return warnings
+}
+
+// isRunwayDesignator reports whether group designates one or all runways, e.g. "RWY", "RWY22" or "R04L"
+func isRunwayDesignator(group string) bool {
+	if group == "RWY" {
+		return true
+	}
+	number := strings.TrimPrefix(strings.TrimPrefix(group, "RWY"), "R")
+	if len(number) == len(group) || len(number) < 2 {
+		return false
+	}
+	return isDigits(number[:2]) && len(strings.TrimRight(number[2:], "LCR")) == 0
+}
+
+// windGroup returns the surface wind group of the raw METAR text, e.g. '27012G20KT' or 'VRB03KT'
+func (metar *METAR) windGroup() (string, bool) {
+	for _, group := range metar.body() {
+		if _, ok := parseWindGroup(group); ok {
+			return group, true
+		}
+	}
+	return "", false
+}
+
+// windGroupValues contains the values of a wind group in the unit it was reported in
+type windGroupValues struct {
+	direction int
+	variable  bool
+	speed     int
+	gust      int
+	unit      string
+}
+
+// parseWindGroup parses a wind group like '24015G25KT', 'VRB03MPS' or '00000KMH'
+func parseWindGroup(group string) (windGroupValues, bool) {
+	for _, unit := range []string{"KT", "MPS", "KMH"} {
+		value := strings.TrimSuffix(group, unit)
+		if value == group || len(value) < 5 {
+			continue
+		}
+
+		values := windGroupValues{unit: unit}
+		if gust := strings.Index(value, "G"); gust >= 0 {
+			if !isDigits(value[gust+1:]) {
+				return windGroupValues{}, false
+			}
+			values.gust, _ = strconv.Atoi(value[gust+1:])
+			value = value[:gust]
+		}
+		if len(value) < 5 {
+			return windGroupValues{}, false
+		}
+		if (!isDigits(value[:3]) && value[:3] != "VRB") || !isDigits(value[3:]) {
+			return windGroupValues{}, false
+		}
+
+		values.variable = value[:3] == "VRB"
+		values.direction, _ = strconv.Atoi(value[:3])
+		values.speed, _ = strconv.Atoi(value[3:])
+		return values, true
+	}
+	return windGroupValues{}, false
+}
+
+// WindUnit returns the unit the wind is reported in by the raw METAR text, i.e. "KT" (knots), "MPS" (meters per
+// second) or "KMH" (kilometers per hour).
+// An empty string is returned if the raw text contains no valid wind group.
+func (metar *METAR) WindUnit() string {
+	group, ok := metar.windGroup()
+	if !ok {
+		return ""
+	}
+	values, _ := parseWindGroup(group)
+	return values.unit
+}
+
+// IsCalm reports whether the METAR reports calm wind, i.e. a '00000KT' wind group.
+// Calm wind is distinguishable from missing wind data only if the raw text (and thus the wind group) is present; see
+// IsWindMissing.
+func (metar *METAR) IsCalm() bool {
+	group, ok := metar.windGroup()
+	return ok && strings.HasPrefix(group, "00000")
+}
+
+// IsVariableWind reports whether the wind direction is variable.
+// This is the case if the raw text reports a 'VRB' wind group or, if no wind group is present, the wind has a speed but
+// no direction.
+func (metar *METAR) IsVariableWind() bool {
+	if group, ok := metar.windGroup(); ok {
+		return strings.HasPrefix(group, "VRB")
+	}
+	return metar.WindDirDegrees == 0 && metar.WindSpeedKT > 0
+}
+
+// IsWindMissing reports whether the METAR lacks wind data.
+// This is the case if the raw text contains no valid wind group (e.g. '/////KT') or, if the raw text is absent, neither
+// a wind direction nor speed is set.
+// Calm, variable and missing wind are mutually exclusive.
+func (metar *METAR) IsWindMissing() bool {
+	if metar.RawText != "" {
+		_, ok := metar.windGroup()
+		return !ok
+	}
+	return metar.WindDirDegrees == 0 && metar.WindSpeedKT == 0
+}
+
+// WindVector returns the east-west (u) and north-south (v) components of the wind in knots.
+// Following the meteorological convention, positive u values denote wind blowing towards the east and positive v values
+// denote wind blowing towards the north.
+// ok is false for calm and variable winds as there is no meaningful direction.
+func (metar *METAR) WindVector() (u, v float32, ok bool) {
+	if metar.WindSpeedKT == 0 || metar.IsVariableWind() {
+		return 0, 0, false
+	}
+
+	direction := float64(metar.WindDirDegrees) * math.Pi / 180
+	speed := float64(metar.WindSpeedKT)
+	return float32(-speed * math.Sin(direction)), float32(-speed * math.Cos(direction)), true
+}
+
+// compassPoint represents one of the 16 points of the compass
+type compassPoint struct {
+	abbreviation, name string
+}
+
+var compassPoints = []compassPoint{
+	{"N", "north"},
+	{"NNE", "north-northeast"},
+	{"NE", "northeast"},
+	{"ENE", "east-northeast"},
+	{"E", "east"},
+	{"ESE", "east-southeast"},
+	{"SE", "southeast"},
+	{"SSE", "south-southeast"},
+	{"S", "south"},
+	{"SSW", "south-southwest"},
+	{"SW", "southwest"},
+	{"WSW", "west-southwest"},
+	{"W", "west"},
+	{"WNW", "west-northwest"},
+	{"NW", "northwest"},
+	{"NNW", "north-northwest"},
+}
+
+// compassPointOf returns the compass point whose 22.5° sector contains the given direction
+func compassPointOf(degrees int) compassPoint {
+	normalized := math.Mod(float64(degrees), 360)
+	if normalized < 0 {
+		normalized += 360
+	}
+	return compassPoints[int(math.Floor(normalized/22.5+0.5))%len(compassPoints)]
+}
+
+// WindCompass returns the 16-point compass label of the wind direction (e.g. "WNW"), using sectors of 22.5° centered
+// on the respective point.
+// "Calm" and "Variable" are returned for calm and variable winds and an empty string if the wind is missing.
+func (metar *METAR) WindCompass() string {
+	switch {
+	case metar.IsWindMissing():
+		return ""
+	case metar.IsCalm():
+		return "Calm"
+	case metar.IsVariableWind():
+		return "Variable"
+	default:
+		return compassPointOf(metar.WindDirDegrees).abbreviation
+	}
+}
+
+// WindComponents splits the sustained wind into its components relative to a runway with the given magnetic or true
+// heading in degrees.
+// Positive headwind values denote a headwind and negative ones a tailwind; positive crosswind values denote wind from
+// the right and negative ones wind from the left. Calm winds have no components. ok is false for variable and missing
+// winds.
+// Please keep in mind that the wind direction of a METAR refers to true north while runway headings usually refer to
+// magnetic north.
+func (metar *METAR) WindComponents(runwayHeadingDeg int) (headwind, crosswind float32, ok bool) {
+	return metar.windComponents(runwayHeadingDeg, metar.WindSpeedKT)
+}
+
+// MaxCrosswind returns the magnitude of the worst-case crosswind relative to a runway with the given heading in
+// degrees, i.e. the crosswind component of the gusts or, if no gusts are reported, of the sustained wind.
+// ok is false for variable and missing winds; please refer to WindComponents for further information.
+func (metar *METAR) MaxCrosswind(runwayHeadingDeg int) (float32, bool) {
+	speed := metar.WindSpeedKT
+	if metar.WindGustKT > speed {
+		speed = metar.WindGustKT
+	}
+	_, crosswind, ok := metar.windComponents(runwayHeadingDeg, speed)
+	return float32(math.Abs(float64(crosswind))), ok
+}
+
+func (metar *METAR) windComponents(runwayHeadingDeg, speedKT int) (headwind, crosswind float32, ok bool) {
+	if metar.IsWindMissing() || metar.IsVariableWind() {
+		return 0, 0, false
+	}
+	if metar.IsCalm() || metar.WindSpeedKT == 0 {
+		return 0, 0, true
+	}
+
+	angle := float64(metar.WindDirDegrees-runwayHeadingDeg) * math.Pi / 180
+	speed := float64(speedKT)
+	return float32(speed * math.Cos(angle)), float32(speed * math.Sin(angle)), true
+}
+
+// PeakWind parses the peak wind remark ('PK WND dddff/hhmm' or 'PK WND dddff/mm') of the METAR, which reports the
+// highest instantaneous wind speed since the last routine report, e.g. 'PK WND 28045/1515'.
+// The time of the peak wind is resolved against the observation time: if only the minutes are given, the hour of the
+// observation is assumed; times after the observation are moved to the previous hour or day respectively. ok is false
+// if the remark is absent or malformed or the observation time can not be parsed.
+func (metar *METAR) PeakWind() (dirDeg int, speedKT int, at time.Time, ok bool) {
+	groups := metar.remarks()
+	for i := 0; i+2 < len(groups); i++ {
+		if groups[i] != "PK" || groups[i+1] != "WND" {
+			continue
+		}
+
+		group := groups[i+2]
+		slash := strings.Index(group, "/")
+		if slash < 5 || slash > 6 || !isDigits(group[:slash]) || !isDigits(group[slash+1:]) {
+			return 0, 0, time.Time{}, false
+		}
+		observedAt, err := metar.ObservedAt()
+		if err != nil {
+			return 0, 0, time.Time{}, false
+		}
+		observedAt = observedAt.UTC()
+
+		dirDeg, _ = strconv.Atoi(group[:3])
+		speedKT, _ = strconv.Atoi(group[3:slash])
+		clock := group[slash+1:]
+		if dirDeg > 360 || (len(clock) != 2 && len(clock) != 4) {
+			return 0, 0, time.Time{}, false
+		}
+
+		hour := observedAt.Hour()
+		if len(clock) == 4 {
+			hour, _ = strconv.Atoi(clock[:2])
+		}
+		minute, _ := strconv.Atoi(clock[len(clock)-2:])
+		if hour > 23 || minute > 59 {
+			return 0, 0, time.Time{}, false
+		}
+
+		at = time.Date(observedAt.Year(), observedAt.Month(), observedAt.Day(), hour, minute, 0, 0, time.UTC)
+		if at.After(observedAt) && len(clock) == 2 {
+			at = at.Add(-time.Hour)
+		} else if at.After(observedAt) {
+			at = at.AddDate(0, 0, -1)
+		}
+		return dirDeg, speedKT, at, true
+	}
+	return 0, 0, time.Time{}, false
+}
@@ -0,0 +1,108 @@
+package awc
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseWindGroup(t *testing.T) {
+	tests := []struct {
+		group string
+		want  windGroupValues
+		ok    bool
+	}{
+		{"24015KT", windGroupValues{direction: 240, speed: 15, unit: "KT"}, true},
+		{"24015G25KT", windGroupValues{direction: 240, speed: 15, gust: 25, unit: "KT"}, true},
+		{"VRB03KT", windGroupValues{variable: true, speed: 3, unit: "KT"}, true},
+		{"00000KT", windGroupValues{unit: "KT"}, true},
+		{"270105G130KT", windGroupValues{direction: 270, speed: 105, gust: 130, unit: "KT"}, true},
+		{"27012MPS", windGroupValues{direction: 270, speed: 12, unit: "MPS"}, true},
+		{"09020KMH", windGroupValues{direction: 90, speed: 20, unit: "KMH"}, true},
+		{"12G45KT", windGroupValues{}, false},
+		{"G1234KT", windGroupValues{}, false},
+		{"1234G45KT", windGroupValues{}, false},
+		{"24015GKT", windGroupValues{}, false},
+		{"KT", windGroupValues{}, false},
+		{"MPS", windGroupValues{}, false},
+		{"/////KT", windGroupValues{}, false},
+		{"ABC12KT", windGroupValues{}, false},
+		{"24015", windGroupValues{}, false},
+	}
+	for _, test := range tests {
+		got, ok := parseWindGroup(test.group)
+		if ok != test.ok || got != test.want {
+			t.Errorf("parseWindGroup(%q) = %+v, %t; want %+v, %t", test.group, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestWindHelpersWithTruncatedGroups(t *testing.T) {
+	for _, raw := range []string{"KXYZ 011256Z 12G45KT 10SM", "KXYZ 011256Z G1234KT 10SM", "KXYZ 011256Z KT 10SM"} {
+		metar := &METAR{RawText: raw}
+		if !metar.IsWindMissing() || metar.IsCalm() || metar.IsVariableWind() {
+			t.Errorf("%q: expected missing wind", raw)
+		}
+		if _, _, ok := metar.WindComponents(90); ok {
+			t.Errorf("%q: expected no wind components", raw)
+		}
+	}
+}
+
+func TestWindShearWarnings(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want []string
+	}{
+		{"KDEN 011253Z 25025G40KT 10SM FEW080 22/M02 A3001 WS RWY35L RMK AO2", []string{"RWY35L"}},
+		{"HKJK 011300Z 09012KT 9999 FEW025 24/13 Q1020 WS ALL RWY", []string{"ALL RWY"}},
+		{"LPMA 011300Z 36020G32KT 9999 FEW020 19/12 Q1019 WS TKOF RWY05 WS LDG RWY23",
+			[]string{"TKOF RWY05", "LDG RWY23"}},
+		{"KSFO 011256Z 28012KT 10SM FEW020 15/08 A3002 RMK AO2 WS RWY28L", nil},
+		{"KSFO 011256Z 28012KT 10SM FEW020 15/08 A3002", nil},
+		{"KSFO 011256Z 28012KT 10SM WS 15/08 A3002", nil},
+	}
+	for _, test := range tests {
+		if got := (&METAR{RawText: test.raw}).WindShearWarnings(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("WindShearWarnings(%q) = %q; want %q", test.raw, got, test.want)
+		}
+	}
+}
+
+func TestPeakWind(t *testing.T) {
+	tests := []struct {
+		raw, observationTime string
+		dirDeg, speedKT      int
+		at                   time.Time
+		ok                   bool
+	}{
+		{"KSFO 011556Z 28032G45KT 10SM 15/08 A3002 RMK AO2 PK WND 28045/1515 SLP165", "2024-05-01T15:56:00Z",
+			280, 45, time.Date(2024, time.May, 1, 15, 15, 0, 0, time.UTC), true},
+		{"KSFO 011556Z 28032G45KT 10SM 15/08 A3002 RMK AO2 PK WND 28045/15", "2024-05-01T15:56:00Z",
+			280, 45, time.Date(2024, time.May, 1, 15, 15, 0, 0, time.UTC), true},
+		{"KSFO 011612Z 28032G45KT 10SM 15/08 A3002 RMK AO2 PK WND 280105/58", "2024-05-01T16:12:00Z",
+			280, 105, time.Date(2024, time.May, 1, 15, 58, 0, 0, time.UTC), true},
+		{"KSFO 010012Z 28032G45KT 10SM 15/08 A3002 RMK AO2 PK WND 28045/2350", "2024-05-01T00:12:00Z",
+			280, 45, time.Date(2024, time.April, 30, 23, 50, 0, 0, time.UTC), true},
+		{"KSFO 010012Z 28032G45KT 10SM 15/08 A3002 RMK AO2 PK WND 28045/50", "2024-05-01T00:12:00Z",
+			280, 45, time.Date(2024, time.April, 30, 23, 50, 0, 0, time.UTC), true},
+		{"KSFO 011556Z 28032G45KT 10SM 15/08 A3002 RMK AO2 PK WND 28045/1515", "", 0, 0, time.Time{}, false},
+		{"KSFO 011556Z 28032G45KT 10SM 15/08 A3002 RMK AO2 PK WND 28045/2515", "2024-05-01T15:56:00Z",
+			0, 0, time.Time{}, false},
+		{"KSFO 011556Z 28032G45KT 10SM 15/08 A3002 RMK AO2 PK WND 37045/1515", "2024-05-01T15:56:00Z",
+			0, 0, time.Time{}, false},
+		{"KSFO 011556Z 28032G45KT 10SM 15/08 A3002 RMK AO2 PK WND 28045/151", "2024-05-01T15:56:00Z",
+			0, 0, time.Time{}, false},
+		{"KSFO 011556Z 28032G45KT 10SM 15/08 A3002 RMK AO2 SLP165", "2024-05-01T15:56:00Z",
+			0, 0, time.Time{}, false},
+		{"KSFO 011556Z 28032G45KT 10SM 15/08 A3002", "2024-05-01T15:56:00Z", 0, 0, time.Time{}, false},
+	}
+	for _, test := range tests {
+		metar := &METAR{RawText: test.raw, ObservationTime: test.observationTime}
+		dirDeg, speedKT, at, ok := metar.PeakWind()
+		if ok != test.ok || dirDeg != test.dirDeg || speedKT != test.speedKT || !at.Equal(test.at) {
+			t.Errorf("PeakWind(%q) = %d, %d, %s, %t; want %d, %d, %s, %t", test.raw, dirDeg, speedKT, at, ok,
+				test.dirDeg, test.speedKT, test.at, test.ok)
+		}
+	}
+}